@@ -0,0 +1,190 @@
+package reader
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+// base64Sextets encodes to the 64 characters of a base64 alphabet in order,
+// its 6-bit groups being 0, 1, ..., 63.
+var base64Sextets = func() (b [48]byte) {
+	for i := 0; i < 64; i += 4 {
+		b[i/4*3+0] = byte(i<<2 | (i+1)>>4)
+		b[i/4*3+1] = byte((i+1)<<4 | (i+2)>>2)
+		b[i/4*3+2] = byte((i+2)<<6 | (i + 3))
+	}
+	return b
+}()
+
+// base64Alphabet reports which bytes are in the alphabet of enc and
+// returns its padding character, if any.
+func base64Alphabet(enc *base64.Encoding) (alphabet [256]bool, pad int) {
+	var buf [64]byte
+	enc.Encode(buf[:], base64Sextets[:])
+	for _, c := range buf {
+		alphabet[c] = true
+	}
+
+	pad = -1
+	if enc.EncodedLen(1) == 4 {
+		enc.Encode(buf[:4], []byte{0})
+		pad = int(buf[3])
+	}
+	return alphabet, pad
+}
+
+// ReadBase64Chunk decodes base64 data encoded with enc, starting at the
+// current position, into dst, and advances the Reader past the characters
+// it decoded. It stops when dst is full or at the first byte that is
+// neither in the alphabet of enc nor padding, such as a line ending,
+// so a ReadBase64Chunk returning 0, nil means the unread data does not
+// start with base64.
+// The data is decoded in groups of four characters, so to make progress
+// dst must hold at least three bytes, unless only a final short group
+// remains. If not even the first group fits, ReadBase64Chunk returns
+// io.ErrShortBuffer. If no data remains, it returns io.EOF.
+// Malformed input yields an error wrapping base64.CorruptInputError,
+// which holds the absolute offset of the offending character; the
+// groups before it are decoded and consumed.
+func (r *Reader[S]) ReadBase64Chunk(enc *base64.Encoding, dst []byte) (n int, err error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+
+	alphabet, pad := base64Alphabet(enc)
+	end := 0
+	for end < len(s) && alphabet[s[end]] {
+		end++
+	}
+	if end/4*3+end%4*6/8 <= len(dst) {
+		// All of the run fits, including any padding of its final group.
+		for i := end % 4; i > 0 && i < 4 && end < len(s) && int(s[end]) == pad; i++ {
+			end++
+		}
+	} else {
+		// Decode as many whole groups as fit.
+		end = len(dst) / 3 * 4
+	}
+	if end == 0 {
+		if len(dst) == 0 || !alphabet[s[0]] {
+			return 0, nil
+		}
+		return 0, io.ErrShortBuffer
+	}
+
+	n, err = enc.Decode(dst, asBytes(s[:end]))
+	if err != nil {
+		var e base64.CorruptInputError
+		if !errors.As(err, &e) {
+			return 0, err
+		}
+		// Keep the whole groups decoded before the offending one.
+		end = int(e) / 4 * 4
+		n = end / 4 * 3
+		err = &ReaderError{Method: "ReadBase64Chunk", Offset: r.off + int64(e), Cause: base64.CorruptInputError(r.off + int64(e))}
+	}
+	r.off += int64(end)
+	return n, err
+}
+
+// ReadBase64 decodes n bytes of base64 data encoded with enc, consuming
+// exactly the characters that encode them, including any padding, and
+// returns the decoded bytes. As with enc.Decode, '\r' and '\n' between
+// the characters are skipped.
+// If the data ends first, ReadBase64 returns io.ErrUnexpectedEOF.
+// Malformed input yields an error wrapping base64.CorruptInputError,
+// which holds the absolute offset of the first offending character.
+// On error the Reader is not advanced. It returns an error if n is negative.
+func (r *Reader[S]) ReadBase64(enc *base64.Encoding, n int) ([]byte, error) {
+	r.lastRead = opInvalid
+	if n < 0 {
+		return nil, &ReaderError{Method: "ReadBase64", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	s := asString(r.unread())
+	alphabet, pad := base64Alphabet(enc)
+
+	end, want := 0, enc.EncodedLen(n)
+	for ; want > 0; end++ {
+		if end == len(s) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		switch c := s[end]; {
+		case c == '\r' || c == '\n':
+		case alphabet[c] || int(c) == pad:
+			want--
+		default:
+			return nil, &ReaderError{Method: "ReadBase64", Offset: r.off + int64(end), Cause: base64.CorruptInputError(r.off + int64(end))}
+		}
+	}
+	b, err := r.decodeBase64("ReadBase64", enc, s[:end])
+	if err == nil && len(b) != n {
+		// The characters encode more than n bytes, so padding is missing
+		// where the encoding of n bytes ends.
+		i, k := 0, base64.RawStdEncoding.EncodedLen(n)
+		for ; k > 0 || s[i] == '\r' || s[i] == '\n'; i++ {
+			if s[i] != '\r' && s[i] != '\n' {
+				k--
+			}
+		}
+		return nil, &ReaderError{Method: "ReadBase64", Offset: r.off + int64(i), Cause: base64.CorruptInputError(r.off + int64(i))}
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.off += int64(end)
+	return b, nil
+}
+
+// ReadBase64Until decodes base64 data encoded with enc up to the first
+// instance of delim, such as '"' or '-', and advances the Reader past
+// the delimiter. As with enc.Decode, '\r' and '\n' are skipped, unless
+// one of them is delim.
+// If delim is not present, ReadBase64Until returns io.ErrUnexpectedEOF.
+// Malformed input yields an error wrapping base64.CorruptInputError,
+// which holds the absolute offset of the first offending character.
+// On error the Reader is not advanced.
+func (r *Reader[S]) ReadBase64Until(enc *base64.Encoding, delim byte) ([]byte, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	alphabet, pad := base64Alphabet(enc)
+
+	end := 0
+	for ; ; end++ {
+		if end == len(s) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		c := s[end]
+		if c == delim {
+			break
+		}
+		if !alphabet[c] && int(c) != pad && c != '\r' && c != '\n' {
+			return nil, &ReaderError{Method: "ReadBase64Until", Offset: r.off + int64(end), Cause: base64.CorruptInputError(r.off + int64(end))}
+		}
+	}
+	b, err := r.decodeBase64("ReadBase64Until", enc, s[:end])
+	if err != nil {
+		return nil, err
+	}
+	r.off += int64(end + 1)
+	return b, nil
+}
+
+// decodeBase64 decodes src, the unread data up to some point, which holds
+// only characters of the alphabet of enc, padding and line endings.
+func (r *Reader[S]) decodeBase64(method string, enc *base64.Encoding, src string) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(src)))
+	m, err := enc.Decode(dst, asBytes(src))
+	if err != nil {
+		off := r.off
+		var e base64.CorruptInputError
+		if errors.As(err, &e) {
+			off += int64(e)
+			err = base64.CorruptInputError(off)
+		}
+		return nil, &ReaderError{Method: method, Offset: off, Cause: err}
+	}
+	return dst[:m], nil
+}
@@ -0,0 +1,242 @@
+package reader_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderReadBase64Chunk(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBase64Chunk[[]byte](t)
+	testReaderReadBase64Chunk[string](t)
+}
+
+func testReaderReadBase64Chunk[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+			s := enc.EncodeToString(data)
+			for _, size := range []int{3, 4, 5, 7, 64, 200} {
+				r := New(S(s))
+				var got []byte
+				buf := make([]byte, size)
+				for {
+					n, err := r.ReadBase64Chunk(enc, buf)
+					got = append(got, buf[:n]...)
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("ReadBase64Chunk(%d) error = %v", size, err)
+					}
+					if n == 0 {
+						t.Fatalf("ReadBase64Chunk(%d) made no progress at Len %d", size, r.Len())
+					}
+				}
+				if !bytes.Equal(data, got) {
+					t.Errorf("ReadBase64Chunk(%d) = %x; want %x", size, got, data)
+				}
+			}
+		}
+	})
+}
+
+func TestReaderReadBase64ChunkAllocs(t *testing.T) {
+	enc := base64.StdEncoding.WithPadding('*')
+	s := enc.EncodeToString(make([]byte, 100))
+	var buf [128]byte
+	r := New(s)
+	n := testing.AllocsPerRun(10, func() {
+		_, _ = r.Seek(0, io.SeekStart)
+		if n, err := r.ReadBase64Chunk(enc, buf[:]); n != 100 || err != nil {
+			t.Fatalf("ReadBase64Chunk = %d, %v; want 100, nil", n, err)
+		}
+	})
+	if n != 0 {
+		t.Errorf("ReadBase64Chunk allocs = %v; want 0", n)
+	}
+}
+
+func TestReaderReadBase64ChunkStop(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBase64ChunkStop[[]byte](t)
+	testReaderReadBase64ChunkStop[string](t)
+}
+
+func testReaderReadBase64ChunkStop[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("QUJD\nREVGRw==\""))
+		buf := make([]byte, 16)
+		n, err := r.ReadBase64Chunk(base64.StdEncoding, buf)
+		if string(buf[:n]) != "ABC" || err != nil {
+			t.Errorf("ReadBase64Chunk = %q, %v; want %q, nil", buf[:n], err, "ABC")
+		}
+		if n, err := r.ReadBase64Chunk(base64.StdEncoding, buf); n != 0 || err != nil {
+			t.Errorf("at newline: ReadBase64Chunk = %d, %v; want 0, nil", n, err)
+		}
+		_, _ = r.ReadByte()
+		n, err = r.ReadBase64Chunk(base64.StdEncoding, buf)
+		if string(buf[:n]) != "DEFG" || err != nil {
+			t.Errorf("ReadBase64Chunk = %q, %v; want %q, nil", buf[:n], err, "DEFG")
+		}
+		if r.Len() != 1 {
+			t.Errorf("Len = %d; want 1", r.Len())
+		}
+
+		// A final short group decodes into a buffer too small for a whole group.
+		r = New(S("QUI"))
+		buf = make([]byte, 2)
+		n, err = r.ReadBase64Chunk(base64.RawStdEncoding, buf)
+		if string(buf[:n]) != "AB" || err != nil {
+			t.Errorf("ReadBase64Chunk = %q, %v; want %q, nil", buf[:n], err, "AB")
+		}
+
+		r = New(S("QUJD"))
+		if n, err := r.ReadBase64Chunk(base64.StdEncoding, buf); n != 0 || err != io.ErrShortBuffer {
+			t.Errorf("ReadBase64Chunk = %d, %v; want 0, %v", n, err, io.ErrShortBuffer)
+		}
+		if r.Len() != 4 {
+			t.Errorf("Len = %d; want 4", r.Len())
+		}
+	})
+}
+
+func TestReaderReadBase64ChunkCorrupt(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBase64ChunkCorrupt[[]byte](t)
+	testReaderReadBase64ChunkCorrupt[string](t)
+}
+
+func testReaderReadBase64ChunkCorrupt[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("xQUJDQQ=;"))
+		_, _ = r.ReadByte()
+		buf := make([]byte, 16)
+		n, err := r.ReadBase64Chunk(base64.StdEncoding, buf)
+		var e base64.CorruptInputError
+		if string(buf[:n]) != "ABC" || !errors.As(err, &e) {
+			t.Fatalf("ReadBase64Chunk = %q, %v; want %q, CorruptInputError", buf[:n], err, "ABC")
+		}
+		if want := "reader.Reader.ReadBase64Chunk: illegal base64 data at input byte 8 at offset 8"; err.Error() != want {
+			t.Errorf("error = %v; want %s", err, want)
+		}
+		if r.Len() != 4 {
+			t.Errorf("Len = %d; want 4", r.Len())
+		}
+	})
+}
+
+var readBase64Tests = []struct {
+	enc     *base64.Encoding
+	s       string
+	n       int
+	want    string
+	wantLen int
+	wanterr any
+}{
+	{base64.StdEncoding, "QUJD;", 3, "ABC", 1, nil},
+	{base64.StdEncoding, "QQ==QUJD", 1, "A", 4, nil},
+	{base64.StdEncoding, "QUI=", 2, "AB", 0, nil},
+	{base64.RawStdEncoding, "QUIx", 2, "AB", 1, nil},
+	{base64.StdEncoding, "QU\r\nJD\nREVG", 6, "ABCDEF", 0, nil},
+	{base64.URLEncoding, "-_-_", 3, "\xfb\xff\xbf", 0, nil},
+	{base64.StdEncoding, "", 0, "", 0, nil},
+	{base64.StdEncoding, "QUJ", 3, "", 3, io.ErrUnexpectedEOF},
+	{base64.StdEncoding, "QU;D", 3, "", 4, "reader.Reader.ReadBase64: illegal base64 data at input byte 3 at offset 3"},
+	{base64.StdEncoding, "QUJD", 1, "", 4, "reader.Reader.ReadBase64: illegal base64 data at input byte 3 at offset 3"},
+	{base64.StdEncoding, "Q=JD", 3, "", 4, "reader.Reader.ReadBase64: illegal base64 data at input byte 2 at offset 2"},
+	{base64.StdEncoding, "QUJD", -1, "", 4, "reader.Reader.ReadBase64: negative count at offset 1"},
+}
+
+func TestReaderReadBase64(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBase64[[]byte](t)
+	testReaderReadBase64[string](t)
+}
+
+func testReaderReadBase64[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range readBase64Tests {
+			r := New(S("x" + tt.s))
+			_, _ = r.ReadByte()
+			got, err := r.ReadBase64(tt.enc, tt.n)
+			if tt.wanterr != nil {
+				if err == nil || fmt.Sprint(tt.wanterr) != err.Error() {
+					t.Errorf("ReadBase64(%q, %d) error = %v; want %v", tt.s, tt.n, err, tt.wanterr)
+				}
+			} else if tt.want != string(got) || err != nil {
+				t.Errorf("ReadBase64(%q, %d) = %q, %v; want %q, nil", tt.s, tt.n, got, err, tt.want)
+			}
+			if r.Len() != tt.wantLen {
+				t.Errorf("ReadBase64(%q, %d): Len = %d; want %d", tt.s, tt.n, r.Len(), tt.wantLen)
+			}
+		}
+	})
+}
+
+var readBase64UntilTests = []struct {
+	s       string
+	delim   byte
+	want    string
+	wantLen int
+	wanterr any
+}{
+	{`QUJDREVG","next"`, '"', "ABCDEF", 7, nil},
+	{"QUJD\nREVG\n-----END", '-', "ABCDEF", 7, nil},
+	{"QUJD\nREVG\n", '\n', "ABC", 5, nil},
+	{"\"", '"', "", 0, nil},
+	{"QUJD", '"', "", 4, io.ErrUnexpectedEOF},
+	{"QU JD\"", '"', "", 6, "reader.Reader.ReadBase64Until: illegal base64 data at input byte 3 at offset 3"},
+	{"QUJDQ\"", '"', "", 6, "reader.Reader.ReadBase64Until: illegal base64 data at input byte 5 at offset 5"},
+}
+
+func TestReaderReadBase64Until(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBase64Until[[]byte](t)
+	testReaderReadBase64Until[string](t)
+}
+
+func testReaderReadBase64Until[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range readBase64UntilTests {
+			r := New(S("x" + tt.s))
+			_, _ = r.ReadByte()
+			got, err := r.ReadBase64Until(base64.StdEncoding, tt.delim)
+			if tt.wanterr != nil {
+				if err == nil || fmt.Sprint(tt.wanterr) != err.Error() {
+					t.Errorf("ReadBase64Until(%q, %q) error = %v; want %v", tt.s, tt.delim, err, tt.wanterr)
+				}
+			} else if tt.want != string(got) || err != nil {
+				t.Errorf("ReadBase64Until(%q, %q) = %q, %v; want %q, nil", tt.s, tt.delim, got, err, tt.want)
+			}
+			if r.Len() != tt.wantLen {
+				t.Errorf("ReadBase64Until(%q, %q): Len = %d; want %d", tt.s, tt.delim, r.Len(), tt.wantLen)
+			}
+		}
+	})
+}
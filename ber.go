@@ -0,0 +1,143 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// SetStrictDER sets whether ReadBERLength and ReadBERTLV accept only the
+// distinguished encoding rules (DER) subset of BER, rejecting indefinite
+// lengths and lengths and tags not encoded in their shortest form.
+func (r *Reader[S]) SetStrictDER(strict bool) { r.options().strictDER = strict }
+
+// der reports whether the Reader accepts only DER encodings.
+func (r *Reader[S]) der() bool { return r.opts != nil && r.opts.strictDER }
+
+// ReadBERLength reads the length octets of an ASN.1 BER encoding, as
+// defined by X.690, in short form or in long form with up to 8 length
+// bytes. indefinite reports the indefinite-length marker 0x80, in which
+// case length is -1.
+// If no data remains, ReadBERLength returns io.EOF. On error it does not
+// advance; truncated lengths yield an error wrapping io.ErrUnexpectedEOF.
+// Errors report the offset of the length octets.
+func (r *Reader[S]) ReadBERLength() (length int64, indefinite bool, err error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, false, io.EOF
+	}
+	length, n, err := r.berLength("ReadBERLength", s, r.off)
+	if err != nil {
+		return 0, false, err
+	}
+	r.off += int64(n)
+	return length, length < 0, nil
+}
+
+// berLength decodes the length octets at the start of s, which is not
+// empty and lies at offset off, returning the length, or -1 if it is
+// indefinite, and the number of octets.
+func (r *Reader[S]) berLength(method string, s string, off int64) (length int64, n int, err error) {
+	b := s[0]
+	switch {
+	case b < 0x80:
+		return int64(b), 1, nil
+	case b == 0x80:
+		if r.der() {
+			return 0, 0, &ReaderError{Method: method, Offset: off, Cause: fmt.Errorf("%w: indefinite length in DER", ErrSyntax)}
+		}
+		return -1, 1, nil
+	case b == 0xff:
+		return 0, 0, &ReaderError{Method: method, Offset: off, Cause: fmt.Errorf("%w: reserved length octet", ErrSyntax)}
+	}
+
+	n = int(b & 0x7f)
+	if n > 8 {
+		return 0, 0, &ReaderError{Method: method, Offset: off, Cause: fmt.Errorf("length of %d bytes: %w", n, ErrOverflow)}
+	}
+	if len(s) <= n {
+		return 0, 0, &ReaderError{Method: method, Offset: off, Cause: fmt.Errorf("truncated length: %w", io.ErrUnexpectedEOF)}
+	}
+	var x uint64
+	for i := 1; i <= n; i++ {
+		x = x<<8 | uint64(s[i])
+	}
+	if x > math.MaxInt64 {
+		return 0, 0, &ReaderError{Method: method, Offset: off, Cause: fmt.Errorf("length: %w", ErrOverflow)}
+	}
+	if r.der() && (s[1] == 0 || x < 0x80) {
+		return 0, 0, &ReaderError{Method: method, Offset: off, Cause: fmt.Errorf("%w: non-minimal length in DER", ErrSyntax)}
+	}
+	return int64(x), n + 1, nil
+}
+
+// A BERTag is the identifier of an ASN.1 BER encoding.
+type BERTag struct {
+	Class       int  // 0 universal, 1 application, 2 context-specific or 3 private
+	Constructed bool // whether the contents are themselves encodings
+	Number      int
+}
+
+// ReadBERTLV reads a complete ASN.1 BER encoding of definite length,
+// its identifier, length and contents octets, and returns the tag and
+// the contents as a view into the underlying data.
+// If no data remains, ReadBERTLV returns io.EOF. On error it does not
+// advance; an encoding extending past the end of the data yields an
+// error wrapping io.ErrUnexpectedEOF. Indefinite lengths are rejected.
+// Errors report the offset of the offending octets.
+func (r *Reader[S]) ReadBERTLV() (tag BERTag, contents S, err error) {
+	r.lastRead = opInvalid
+	rest := r.unread()
+	s := asString(rest)
+	if len(s) == 0 {
+		return tag, rest, io.EOF
+	}
+
+	b := s[0]
+	tag.Class = int(b >> 6)
+	tag.Constructed = b&0x20 != 0
+	tag.Number = int(b & 0x1f)
+	i := 1
+	if tag.Number == 0x1f {
+		// High-tag-number form: base-128 digits, most significant first.
+		tag.Number = 0
+		for {
+			if i >= len(s) {
+				return BERTag{}, rest[:0], &ReaderError{Method: "ReadBERTLV", Offset: r.off, Cause: fmt.Errorf("truncated tag: %w", io.ErrUnexpectedEOF)}
+			}
+			c := s[i]
+			if r.der() && i == 1 && c == 0x80 {
+				return BERTag{}, rest[:0], &ReaderError{Method: "ReadBERTLV", Offset: r.off, Cause: fmt.Errorf("%w: non-minimal tag in DER", ErrSyntax)}
+			}
+			if tag.Number >= 1<<(31-7) {
+				return BERTag{}, rest[:0], &ReaderError{Method: "ReadBERTLV", Offset: r.off, Cause: fmt.Errorf("tag number: %w", ErrOverflow)}
+			}
+			tag.Number = tag.Number<<7 | int(c&0x7f)
+			i++
+			if c < 0x80 {
+				break
+			}
+		}
+		if r.der() && tag.Number < 0x1f {
+			return BERTag{}, rest[:0], &ReaderError{Method: "ReadBERTLV", Offset: r.off, Cause: fmt.Errorf("%w: non-minimal tag in DER", ErrSyntax)}
+		}
+	}
+
+	if i >= len(s) {
+		return BERTag{}, rest[:0], &ReaderError{Method: "ReadBERTLV", Offset: r.off + int64(i), Cause: fmt.Errorf("truncated length: %w", io.ErrUnexpectedEOF)}
+	}
+	length, n, err := r.berLength("ReadBERTLV", s[i:], r.off+int64(i))
+	if err != nil {
+		return BERTag{}, rest[:0], err
+	}
+	if length < 0 {
+		return BERTag{}, rest[:0], &ReaderError{Method: "ReadBERTLV", Offset: r.off + int64(i), Cause: fmt.Errorf("%w: indefinite length", ErrSyntax)}
+	}
+	i += n
+	if length > int64(len(s)-i) {
+		return BERTag{}, rest[:0], &ReaderError{Method: "ReadBERTLV", Offset: r.off + int64(i), Cause: fmt.Errorf("truncated contents: %w", io.ErrUnexpectedEOF)}
+	}
+	r.off += int64(i) + length
+	return tag, rest[i : i+int(length)], nil
+}
@@ -0,0 +1,223 @@
+package reader_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+var readBERLengthTests = []struct {
+	s          string
+	strict     bool
+	length     int64
+	indefinite bool
+	n          int
+	wanterr    string
+}{
+	{"\x00", false, 0, false, 1, ""},
+	{"\x7f", false, 127, false, 1, ""},
+	{"\x81\x80", false, 128, false, 2, ""},
+	// RSA-2048 public key SEQUENCE, as in a real certificate.
+	{"\x82\x01\x0a", true, 266, false, 3, ""},
+	{"\x88\x7f\xff\xff\xff\xff\xff\xff\xff", false, 1<<63 - 1, false, 9, ""},
+	{"\x80", false, -1, true, 1, ""},
+	{"\x81\x05", false, 5, false, 2, ""},
+	{"\x82\x00\x80", false, 128, false, 3, ""},
+
+	{"\x80", true, 0, false, 0, "reader.Reader.ReadBERLength: syntax error: indefinite length in DER at offset 1"},
+	{"\x81\x05", true, 0, false, 0, "reader.Reader.ReadBERLength: syntax error: non-minimal length in DER at offset 1"},
+	{"\x82\x00\x80", true, 0, false, 0, "reader.Reader.ReadBERLength: syntax error: non-minimal length in DER at offset 1"},
+	{"\xff", false, 0, false, 0, "reader.Reader.ReadBERLength: syntax error: reserved length octet at offset 1"},
+	{"\x89\x01\x00\x00\x00\x00\x00\x00\x00\x00", false, 0, false, 0, "reader.Reader.ReadBERLength: length of 9 bytes: integer overflow at offset 1"},
+	{"\x88\x80\x00\x00\x00\x00\x00\x00\x00", false, 0, false, 0, "reader.Reader.ReadBERLength: length: integer overflow at offset 1"},
+	{"\x82\x01", false, 0, false, 0, "reader.Reader.ReadBERLength: truncated length: unexpected EOF at offset 1"},
+}
+
+func TestReaderReadBERLength(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBERLength[[]byte](t)
+	testReaderReadBERLength[string](t)
+
+	// The setting survives Reset.
+	r := New("")
+	r.SetStrictDER(true)
+	r.Reset("\x81\x7f")
+	if _, _, err := r.ReadBERLength(); err == nil {
+		t.Errorf("after Reset: ReadBERLength error = nil; want DER error")
+	}
+}
+
+func testReaderReadBERLength[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range readBERLengthTests {
+			r := New(S("x" + tt.s))
+			_, _ = r.ReadByte()
+			r.SetStrictDER(tt.strict)
+			length, indefinite, err := r.ReadBERLength()
+			if tt.wanterr != "" {
+				if err == nil || tt.wanterr != err.Error() {
+					t.Errorf("ReadBERLength(%q) error = %v; want %s", tt.s, err, tt.wanterr)
+				}
+				if r.Len() != len(tt.s) {
+					t.Errorf("ReadBERLength(%q): Len = %d; want %d", tt.s, r.Len(), len(tt.s))
+				}
+				continue
+			}
+			if tt.length != length || tt.indefinite != indefinite || err != nil {
+				t.Errorf("ReadBERLength(%q) = %d, %t, %v; want %d, %t, nil", tt.s, length, indefinite, err, tt.length, tt.indefinite)
+			}
+			if r.Len() != len(tt.s)-tt.n {
+				t.Errorf("ReadBERLength(%q): Len = %d; want %d", tt.s, r.Len(), len(tt.s)-tt.n)
+			}
+		}
+
+		if _, _, err := New(S("")).ReadBERLength(); err != io.EOF {
+			t.Errorf("at EOF: ReadBERLength error = %v; want EOF", err)
+		}
+	})
+}
+
+func TestReaderReadBERTLV(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBERTLV[[]byte](t)
+	testReaderReadBERTLV[string](t)
+}
+
+func testReaderReadBERTLV[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s        string
+		strict   bool
+		tag      BERTag
+		contents string
+		wanterr  string
+	}{
+		{"\x02\x01\x05", true, BERTag{0, false, 2}, "\x05", ""},
+		{"\x30\x03\x02\x01\x05", true, BERTag{0, true, 16}, "\x02\x01\x05", ""},
+		{"\x05\x00", true, BERTag{0, false, 5}, "", ""},
+		{"\xa0\x03\x02\x01\x02", true, BERTag{2, true, 0}, "\x02\x01\x02", ""},
+		{"\x5f\x81\x00\x01x", true, BERTag{1, false, 128}, "x", ""},
+		{"\x1f\x05\x00", false, BERTag{0, false, 5}, "", ""},
+
+		{"\x1f\x05\x00", true, BERTag{}, "", "reader.Reader.ReadBERTLV: syntax error: non-minimal tag in DER at offset 0"},
+		{"\x1f\x80\x81\x00\x00", true, BERTag{}, "", "reader.Reader.ReadBERTLV: syntax error: non-minimal tag in DER at offset 0"},
+		{"\x1f\x88\x80\x80\x80\x00\x00", false, BERTag{}, "", "reader.Reader.ReadBERTLV: tag number: integer overflow at offset 0"},
+		{"\x1f\x81", false, BERTag{}, "", "reader.Reader.ReadBERTLV: truncated tag: unexpected EOF at offset 0"},
+		{"\x30", false, BERTag{}, "", "reader.Reader.ReadBERTLV: truncated length: unexpected EOF at offset 1"},
+		{"\x30\x80\x00\x00", false, BERTag{}, "", "reader.Reader.ReadBERTLV: syntax error: indefinite length at offset 1"},
+		{"\x04\x05abc", false, BERTag{}, "", "reader.Reader.ReadBERTLV: truncated contents: unexpected EOF at offset 2"},
+		{"\x04\x81\x01a", true, BERTag{}, "", "reader.Reader.ReadBERTLV: syntax error: non-minimal length in DER at offset 1"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			r.SetStrictDER(tt.strict)
+			tag, contents, err := r.ReadBERTLV()
+			if tt.wanterr != "" {
+				if err == nil || tt.wanterr != err.Error() {
+					t.Errorf("ReadBERTLV(%q) error = %v; want %s", tt.s, err, tt.wanterr)
+				}
+				if r.Len() != len(tt.s) {
+					t.Errorf("ReadBERTLV(%q): Len = %d; want %d", tt.s, r.Len(), len(tt.s))
+				}
+				continue
+			}
+			if tt.tag != tag || tt.contents != string(contents) || err != nil {
+				t.Errorf("ReadBERTLV(%q) = %+v, %q, %v; want %+v, %q, nil", tt.s, tag, contents, err, tt.tag, tt.contents)
+			}
+			if r.Len() != 0 {
+				t.Errorf("ReadBERTLV(%q): Len = %d; want 0", tt.s, r.Len())
+			}
+		}
+
+		r := New(S(""))
+		if _, _, err := r.ReadBERTLV(); err != io.EOF {
+			t.Errorf("at EOF: ReadBERTLV error = %v; want EOF", err)
+		}
+	})
+}
+
+func TestReaderReadBERTLVCertificate(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1 << 40),
+		Subject:      pkix.Name{CommonName: "reader.example"},
+		NotBefore:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2034, 1, 1, 0, 0, 0, 0, time.UTC),
+		DNSNames:     []string{"reader.example"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := New(der)
+	r.SetStrictDER(true)
+	tag, certContents, err := r.ReadBERTLV()
+	if err != nil || tag != (BERTag{0, true, 16}) || r.Len() != 0 {
+		t.Fatalf("Certificate: ReadBERTLV = %+v, %v with Len %d; want SEQUENCE, nil with Len 0", tag, err, r.Len())
+	}
+
+	r = New(certContents)
+	r.SetStrictDER(true)
+	off := r.Size() - int64(r.Len())
+	tag, tbs, err := r.ReadBERTLV()
+	if err != nil || tag != (BERTag{0, true, 16}) {
+		t.Fatalf("TBSCertificate: ReadBERTLV = %+v, %v; want SEQUENCE, nil", tag, err)
+	}
+	if raw := certContents[off : r.Size()-int64(r.Len())]; string(raw) != string(cert.RawTBSCertificate) {
+		t.Errorf("TBSCertificate encoding does not match x509")
+	}
+
+	// Each element of the TBSCertificate agrees with encoding/asn1.
+	rest := tbs
+	r = New(tbs)
+	r.SetStrictDER(true)
+	for r.Len() > 0 {
+		tag, contents, err := r.ReadBERTLV()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rv asn1.RawValue
+		if rest, err = asn1.Unmarshal(rest, &rv); err != nil {
+			t.Fatal(err)
+		}
+		if tag != (BERTag{rv.Class, rv.IsCompound, rv.Tag}) || string(contents) != string(rv.Bytes) {
+			t.Errorf("ReadBERTLV = %+v, %x; want %+v, %x", tag, contents, rv, rv.Bytes)
+		}
+	}
+	if len(rest) != 0 {
+		t.Errorf("encoding/asn1 has %d bytes left over", len(rest))
+	}
+
+	// A truncated certificate is reported, not consumed.
+	r = New(der[:len(der)-1])
+	if _, _, err := r.ReadBERTLV(); !errors.Is(err, io.ErrUnexpectedEOF) || r.Len() != len(der)-1 {
+		t.Errorf("truncated: ReadBERTLV error = %v with Len %d; want unexpected EOF with Len %d", err, r.Len(), len(der)-1)
+	}
+}
@@ -0,0 +1,592 @@
+package reader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strings"
+)
+
+// next returns a view of the next n unread bytes and advances past them.
+// If fewer than n bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) next(n int) (S, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if n < 0 || n > len(s) {
+		return s[:0], io.ErrUnexpectedEOF
+	}
+	r.off += int64(n)
+	return s[:n], nil
+}
+
+// Align advances the Reader to the smallest offset at or after the
+// current one that is a multiple of n, counting from the start of the
+// underlying data, and returns the number of padding bytes skipped.
+// If the padding runs past the end of the data, Align returns
+// io.ErrUnexpectedEOF and does not advance. It returns an error if n is
+// not positive.
+func (r *Reader[S]) Align(n int64) (skipped int64, err error) {
+	return r.align("Align", 0, n)
+}
+
+// AlignFrom is like Align but aligns relative to base, the offset of the
+// start of a section, rather than the start of the underlying data.
+func (r *Reader[S]) AlignFrom(base, n int64) (skipped int64, err error) {
+	return r.align("AlignFrom", base, n)
+}
+
+func (r *Reader[S]) align(method string, base, n int64) (int64, error) {
+	r.lastRead = opInvalid
+	if n <= 0 {
+		return 0, &ReaderError{Method: method, Offset: r.off, Cause: ErrInvalidAlignment}
+	}
+
+	pad := (n - ((r.off-base)%n+n)%n) % n
+	if pad > 0 && r.off+pad > r.end() {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.off += pad
+	return pad, nil
+}
+
+// ReadUvarint reads an unsigned integer encoded as by binary.AppendUvarint.
+// It decodes directly from the underlying data and advances the Reader
+// only on success. If no bytes remain, the error is io.EOF.
+// A truncated varint yields an error wrapping io.ErrUnexpectedEOF,
+// and one that does not fit in 64 bits an overflow error; both
+// report the offset at which the varint starts.
+func (r *Reader[S]) ReadUvarint() (uint64, error) { return r.readUvarint("ReadUvarint") }
+
+// ReadVarint reads a signed integer encoded as by binary.AppendVarint.
+// It behaves like ReadUvarint otherwise.
+func (r *Reader[S]) ReadVarint() (int64, error) {
+	ux, err := r.readUvarint("ReadVarint")
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, err
+}
+
+func (r *Reader[S]) readUvarint(method string) (uint64, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+	if s[0] < 0x80 {
+		r.off++
+		return uint64(s[0]), nil
+	}
+
+	var x uint64
+	var shift uint
+	for i := 0; i < len(s) && i < binary.MaxVarintLen64; i++ {
+		b := s[i]
+		if b < 0x80 {
+			if i == binary.MaxVarintLen64-1 && b > 1 {
+				break // overflow
+			}
+			r.off += int64(i + 1)
+			return x | uint64(b)<<shift, nil
+		}
+		x |= uint64(b&0x7f) << shift
+		shift += 7
+	}
+	if len(s) < binary.MaxVarintLen64 {
+		return 0, &ReaderError{Method: method, Offset: r.off, Cause: fmt.Errorf("truncated varint: %w", io.ErrUnexpectedEOF)}
+	}
+	return 0, &ReaderError{Method: method, Offset: r.off, Cause: ErrOverflow}
+}
+
+// ReadPadded reads the next n bytes and then skips the padding needed
+// to make the total number of bytes consumed a multiple of align,
+// n + (align-n%align)%align, as used by formats such as IFF and RIFF.
+// Padding missing at the end of the data is tolerated.
+// If fewer than n bytes remain, ReadPadded returns io.ErrUnexpectedEOF
+// and does not advance. It returns an error if n is negative or align
+// is not positive.
+// For a Reader[[]byte] the returned slice is a view into the underlying data.
+func (r *Reader[S]) ReadPadded(n int, align int) ([]byte, error) {
+	if n < 0 {
+		return nil, &ReaderError{Method: "ReadPadded", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	if align <= 0 {
+		return nil, &ReaderError{Method: "ReadPadded", Offset: r.off, Cause: ErrInvalidAlignment}
+	}
+
+	b, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+	pad := (align - n%align) % align
+	r.off += int64(min(pad, r.Len()))
+	return []byte(b), nil
+}
+
+// ReadCString reads a NUL-terminated string, as used by C and by formats
+// such as ELF, returning the bytes before the NUL and consuming the NUL.
+// The result is a view into the underlying data.
+// If no NUL remains, ReadCString returns the unread data with
+// io.ErrUnexpectedEOF and does not advance, leaving it to the caller to
+// decide whether an unterminated string is acceptable. If no data
+// remains, it returns io.EOF.
+func (r *Reader[S]) ReadCString() (S, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if len(s) == 0 {
+		return s, io.EOF
+	}
+	i := strings.IndexByte(asString(s), 0)
+	if i < 0 {
+		return s, io.ErrUnexpectedEOF
+	}
+	r.off += int64(i + 1)
+	return s[:i], nil
+}
+
+// ReadCStringMax is like ReadCString but looks for the NUL only within
+// the next n+1 bytes, so that the string is at most n bytes long, which
+// guards against unterminated garbage in untrusted input.
+// If a longer string would be read, ReadCStringMax returns an error and
+// does not advance. It returns an error if n is negative.
+func (r *Reader[S]) ReadCStringMax(n int) (S, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if n < 0 {
+		return s[:0], &ReaderError{Method: "ReadCStringMax", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	if len(s) == 0 {
+		return s, io.EOF
+	}
+	i := strings.IndexByte(asString(s[:min(n+1, len(s))]), 0)
+	if i < 0 {
+		if len(s) <= n {
+			return s, io.ErrUnexpectedEOF
+		}
+		return s[:0], &ReaderError{Method: "ReadCStringMax", Offset: r.off, Cause: fmt.Errorf("string %w of %d bytes", ErrTooLong, n)}
+	}
+	r.off += int64(i + 1)
+	return s[:i], nil
+}
+
+// ReadNullPaddedString reads a string stored in a fixed-width field of
+// n bytes padded with NUL bytes, as in FAT directory entries and tar
+// headers. It consumes all n bytes and returns them without the trailing
+// NULs, so that a field entirely of NULs yields "".
+// If fewer than n bytes remain, ReadNullPaddedString returns
+// io.ErrUnexpectedEOF and does not advance. It returns an error if n is
+// negative.
+func (r *Reader[S]) ReadNullPaddedString(n int) (string, error) {
+	if n < 0 {
+		return "", &ReaderError{Method: "ReadNullPaddedString", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	b, err := r.next(n)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\x00"), nil
+}
+
+// ReadLenPrefixed reads an unsigned length of width bytes, 1, 2, 4 or 8,
+// in the given byte order, followed by that many bytes, which are
+// returned as a view into the underlying data, as used by Pascal strings
+// and many length-prefixed wire formats. For width 1, order is unused and
+// may be nil.
+// If the data ends before the length or the bytes it declares,
+// ReadLenPrefixed returns io.ErrUnexpectedEOF and does not advance;
+// no length, however large, is trusted beyond the data at hand.
+// It returns an error if width is not 1, 2, 4 or 8.
+func (r *Reader[S]) ReadLenPrefixed(width int, order binary.ByteOrder) (S, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if !validUintWidth(width) {
+		return s[:0], &ReaderError{Method: "ReadLenPrefixed", Offset: r.off, Cause: fmt.Errorf("%w %d", ErrInvalidWidth, width)}
+	}
+	if len(s) < width {
+		return s[:0], io.ErrUnexpectedEOF
+	}
+
+	n := decodeUint(asBytes(s[:width]), order)
+	// Compare as uint64 so that lengths beyond the range of int,
+	// as on 32-bit platforms, are rejected rather than wrapped.
+	if n > uint64(len(s)-width) {
+		return s[:0], io.ErrUnexpectedEOF
+	}
+	r.off += int64(width) + int64(n)
+	return s[width : width+int(n)], nil
+}
+
+func validUintWidth(width int) bool {
+	return width == 1 || width == 2 || width == 4 || width == 8
+}
+
+// decodeUint decodes b, of a width accepted by validUintWidth, as an
+// unsigned integer in the given byte order.
+func decodeUint(b []byte, order binary.ByteOrder) uint64 {
+	switch len(b) {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(order.Uint16(b))
+	case 4:
+		return uint64(order.Uint32(b))
+	default:
+		return order.Uint64(b)
+	}
+}
+
+// ReadVariableField reads a record made of consecutive fields whose
+// widths are given by lengths, returning one slice per field.
+// A zero width for the last field reads the rest of the data; a zero
+// width elsewhere yields an empty field.
+// If the data ends before the record does, ReadVariableField returns
+// io.ErrUnexpectedEOF and does not advance.
+// For a Reader[[]byte] the fields are views into the underlying data.
+func (r *Reader[S]) ReadVariableField(lengths []int) ([][]byte, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	total := 0
+	for i, n := range lengths {
+		if n < 0 {
+			return nil, &ReaderError{Method: "ReadVariableField", Offset: r.off, Cause: ErrNegativeCount}
+		}
+		if n == 0 && i == len(lengths)-1 {
+			n = len(s) - total
+		}
+		if n > len(s)-total {
+			return nil, io.ErrUnexpectedEOF
+		}
+		total += n
+	}
+
+	fields := make([][]byte, len(lengths))
+	for i, n := range lengths {
+		if n == 0 && i == len(lengths)-1 {
+			n = len(s)
+		}
+		fields[i] = []byte(s[:n])
+		s = s[n:]
+	}
+	r.off += int64(total)
+	return fields, nil
+}
+
+// ReadBinary decodes fixed-size data from the Reader in the given byte
+// order, as binary.Read does, but directly from the underlying data
+// without an intermediate buffer. data must be a pointer to a
+// fixed-size value or a slice of fixed-size values.
+// The Reader is advanced by binary.Size(data) only on success; if fewer
+// bytes remain, ReadBinary returns io.ErrUnexpectedEOF and does not advance.
+func (r *Reader[S]) ReadBinary(order binary.ByteOrder, data any) error {
+	n := binary.Size(data)
+	if n < 0 {
+		return &ReaderError{Method: "ReadBinary", Offset: r.off, Cause: fmt.Errorf("%w %T", ErrInvalidType, data)}
+	}
+
+	start := r.off
+	b, err := r.next(n)
+	if err != nil {
+		return err
+	}
+	if _, err := binary.Decode(asBytes(b), order, data); err != nil {
+		r.off = start
+		return err
+	}
+	return nil
+}
+
+// ReadStruct decodes a value of the fixed-size type T, such as a struct
+// of fixed-size fields, from r in the given byte order, as ReadBinary
+// does, and returns it. For example:
+//
+//	hdr, err := reader.ReadStruct[Header](r, binary.BigEndian)
+//
+// The Reader is advanced by binary.Size of the value only on success;
+// if fewer bytes remain, ReadStruct returns io.ErrUnexpectedEOF and does
+// not advance.
+func ReadStruct[T any, S ~[]byte | ~string](r *Reader[S], order binary.ByteOrder) (T, error) {
+	var v T
+	err := r.ReadBinary(order, &v)
+	return v, err
+}
+
+// ReadUint8 reads a single byte as a uint8.
+// If no bytes remain, it returns io.ErrUnexpectedEOF.
+func (r *Reader[S]) ReadUint8() (uint8, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// ReadUint16 reads a uint16 in the given byte order.
+// If fewer than 2 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadUint16(order binary.ByteOrder) (uint16, error) {
+	b, err := r.next(2)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint16(asBytes(b)), nil
+}
+
+// ReadBigEndianUint24 reads a big-endian 24-bit unsigned integer, as used
+// by FLAC and AIFF, into the low bits of a uint32.
+// If fewer than 3 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadBigEndianUint24() (uint32, error) {
+	b, err := r.next(3)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[2]) | uint32(b[1])<<8 | uint32(b[0])<<16, nil
+}
+
+// ReadLittleEndianUint24 reads a little-endian 24-bit unsigned integer,
+// as used by WAV, into the low bits of a uint32.
+// If fewer than 3 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadLittleEndianUint24() (uint32, error) {
+	b, err := r.next(3)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16, nil
+}
+
+// ReadUint32 reads a uint32 in the given byte order.
+// If fewer than 4 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadUint32(order binary.ByteOrder) (uint32, error) {
+	b, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint32(asBytes(b)), nil
+}
+
+// ReadUint64 reads a uint64 in the given byte order.
+// If fewer than 8 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadUint64(order binary.ByteOrder) (uint64, error) {
+	b, err := r.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint64(asBytes(b)), nil
+}
+
+// ReadInt8 reads a single byte as an int8.
+// If no bytes remain, it returns io.ErrUnexpectedEOF.
+func (r *Reader[S]) ReadInt8() (int8, error) {
+	v, err := r.ReadUint8()
+	return int8(v), err
+}
+
+// ReadInt16 reads an int16 in the given byte order, as with ReadUint16.
+func (r *Reader[S]) ReadInt16(order binary.ByteOrder) (int16, error) {
+	v, err := r.ReadUint16(order)
+	return int16(v), err
+}
+
+// ReadBigEndianInt24 reads a big-endian 24-bit two's-complement integer,
+// as used by AIFF samples, sign-extended to an int32, as with
+// ReadBigEndianUint24.
+func (r *Reader[S]) ReadBigEndianInt24() (int32, error) {
+	v, err := r.ReadBigEndianUint24()
+	return int32(v<<8) >> 8, err
+}
+
+// ReadLittleEndianInt24 reads a little-endian 24-bit two's-complement
+// integer, as used by WAV samples, sign-extended to an int32, as with
+// ReadLittleEndianUint24.
+func (r *Reader[S]) ReadLittleEndianInt24() (int32, error) {
+	v, err := r.ReadLittleEndianUint24()
+	return int32(v<<8) >> 8, err
+}
+
+// ReadInt32 reads an int32 in the given byte order, as with ReadUint32.
+func (r *Reader[S]) ReadInt32(order binary.ByteOrder) (int32, error) {
+	v, err := r.ReadUint32(order)
+	return int32(v), err
+}
+
+// ReadInt64 reads an int64 in the given byte order, as with ReadUint64.
+func (r *Reader[S]) ReadInt64(order binary.ByteOrder) (int64, error) {
+	v, err := r.ReadUint64(order)
+	return int64(v), err
+}
+
+// ReadFloat32 reads an IEEE 754 single-precision float in the given
+// byte order. The bits are preserved exactly, including NaN payloads.
+// If fewer than 4 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadFloat32(order binary.ByteOrder) (float32, error) {
+	v, err := r.ReadUint32(order)
+	return math.Float32frombits(v), err
+}
+
+// ReadFloat64 reads an IEEE 754 double-precision float in the given
+// byte order. The bits are preserved exactly, including NaN payloads.
+// If fewer than 8 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadFloat64(order binary.ByteOrder) (float64, error) {
+	v, err := r.ReadUint64(order)
+	return math.Float64frombits(v), err
+}
+
+// ReadIPv4 reads a 4-byte IPv4 address in network order, as found in
+// IP headers, and returns it as a copy.
+// If fewer than 4 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadIPv4() (net.IP, error) {
+	b, err := r.next(net.IPv4len)
+	if err != nil {
+		return nil, err
+	}
+	return net.IP(bytes.Clone(asBytes(b))), nil
+}
+
+// ReadMACAddress reads a 6-byte IEEE 802 MAC-48 address, as found in
+// Ethernet frames, and returns it as a copy.
+// If fewer than 6 bytes remain, it returns io.ErrUnexpectedEOF and
+// does not advance.
+func (r *Reader[S]) ReadMACAddress() (net.HardwareAddr, error) {
+	b, err := r.next(6)
+	if err != nil {
+		return nil, err
+	}
+	return net.HardwareAddr(bytes.Clone(asBytes(b))), nil
+}
+
+// A BinaryReader is a Reader that decodes fixed-size numbers in the
+// byte order chosen at construction. ReadUint8 and ReadInt8 are
+// promoted from the embedded Reader.
+type BinaryReader[S ~[]byte | ~string] struct {
+	*Reader[S]
+	order binary.ByteOrder
+}
+
+// NewBinaryReader returns a new BinaryReader reading from s in the given byte order.
+func NewBinaryReader[S ~[]byte | ~string](s S, order binary.ByteOrder) *BinaryReader[S] {
+	return &BinaryReader[S]{Reader: New(s), order: order}
+}
+
+// ByteOrder returns the byte order of b.
+func (b *BinaryReader[S]) ByteOrder() binary.ByteOrder { return b.order }
+
+// ReadUint16 reads a uint16, as with Reader.ReadUint16.
+func (b *BinaryReader[S]) ReadUint16() (uint16, error) { return b.Reader.ReadUint16(b.order) }
+
+// ReadUint32 reads a uint32, as with Reader.ReadUint32.
+func (b *BinaryReader[S]) ReadUint32() (uint32, error) { return b.Reader.ReadUint32(b.order) }
+
+// ReadUint64 reads a uint64, as with Reader.ReadUint64.
+func (b *BinaryReader[S]) ReadUint64() (uint64, error) { return b.Reader.ReadUint64(b.order) }
+
+// ReadInt16 reads an int16, as with Reader.ReadInt16.
+func (b *BinaryReader[S]) ReadInt16() (int16, error) { return b.Reader.ReadInt16(b.order) }
+
+// ReadInt32 reads an int32, as with Reader.ReadInt32.
+func (b *BinaryReader[S]) ReadInt32() (int32, error) { return b.Reader.ReadInt32(b.order) }
+
+// ReadInt64 reads an int64, as with Reader.ReadInt64.
+func (b *BinaryReader[S]) ReadInt64() (int64, error) { return b.Reader.ReadInt64(b.order) }
+
+// ReadFloat32 reads a float32, as with Reader.ReadFloat32.
+func (b *BinaryReader[S]) ReadFloat32() (float32, error) { return b.Reader.ReadFloat32(b.order) }
+
+// ReadFloat64 reads a float64, as with Reader.ReadFloat64.
+func (b *BinaryReader[S]) ReadFloat64() (float64, error) { return b.Reader.ReadFloat64(b.order) }
+
+// maxLEB128Len is the default maximum length of a LEB128 encoding,
+// the length of the longest minimal encoding of a 64-bit value.
+const maxLEB128Len = binary.MaxVarintLen64
+
+// ReadULEB128 reads an unsigned LEB128-encoded integer, as used by DWARF
+// and WebAssembly, of at most 10 bytes. Non-minimal encodings are
+// accepted as long as the value fits in 64 bits.
+// The Reader is advanced only on success. If no bytes remain, the error
+// is io.EOF; a truncated encoding yields an error wrapping
+// io.ErrUnexpectedEOF. Errors report the offset at which the encoding starts.
+func (r *Reader[S]) ReadULEB128() (uint64, error) {
+	return r.readLEB128("ReadULEB128", maxLEB128Len, false)
+}
+
+// ReadULEB128Max is like ReadULEB128 but rejects encodings longer than
+// limit bytes, guarding against unreasonably padded input.
+func (r *Reader[S]) ReadULEB128Max(limit int) (uint64, error) {
+	return r.readLEB128("ReadULEB128Max", limit, false)
+}
+
+// ReadSLEB128 reads a signed LEB128-encoded integer of at most 10 bytes,
+// sign-extending from the last byte. It behaves like ReadULEB128 otherwise.
+func (r *Reader[S]) ReadSLEB128() (int64, error) {
+	x, err := r.readLEB128("ReadSLEB128", maxLEB128Len, true)
+	return int64(x), err
+}
+
+// ReadSLEB128Max is like ReadSLEB128 but rejects encodings longer than
+// limit bytes, guarding against unreasonably padded input.
+func (r *Reader[S]) ReadSLEB128Max(limit int) (int64, error) {
+	x, err := r.readLEB128("ReadSLEB128Max", limit, true)
+	return int64(x), err
+}
+
+func (r *Reader[S]) readLEB128(method string, limit int, signed bool) (uint64, error) {
+	r.lastRead = opInvalid
+	if limit <= 0 {
+		return 0, &ReaderError{Method: method, Offset: r.off, Cause: fmt.Errorf("%w %d", ErrInvalidWidth, limit)}
+	}
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+
+	var x uint64
+	var shift uint
+	for i := 0; i < len(s); i++ {
+		if i == limit {
+			return 0, &ReaderError{Method: method, Offset: r.off, Cause: fmt.Errorf("LEB128 encoding %w of %d bytes", ErrTooLong, limit)}
+		}
+
+		b := s[i]
+		low := uint64(b & 0x7f)
+		switch {
+		case shift < 63:
+			x |= low << shift
+		case shift == 63:
+			// Only bit 63 is left; the other bits must be zero, or for
+			// a signed value copies of bit 63.
+			if signed && low != 0 && low != 0x7f || !signed && low > 1 {
+				return 0, &ReaderError{Method: method, Offset: r.off, Cause: ErrOverflow}
+			}
+			x |= low << shift
+		default:
+			// Redundant padding must not change the value.
+			var pad uint64
+			if signed && int64(x) < 0 {
+				pad = 0x7f
+			}
+			if low != pad {
+				return 0, &ReaderError{Method: method, Offset: r.off, Cause: ErrOverflow}
+			}
+		}
+		shift += 7
+
+		if b < 0x80 {
+			if signed && shift < 64 && b&0x40 != 0 {
+				x |= ^uint64(0) << shift
+			}
+			r.off += int64(i + 1)
+			return x, nil
+		}
+	}
+	return 0, &ReaderError{Method: method, Offset: r.off, Cause: fmt.Errorf("truncated LEB128: %w", io.ErrUnexpectedEOF)}
+}
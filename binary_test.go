@@ -0,0 +1,1130 @@
+package reader_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderAlign(t *testing.T) {
+	t.Parallel()
+
+	testReaderAlign[[]byte](t)
+	testReaderAlign[string](t)
+}
+
+func testReaderAlign[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		off     int64
+		n       int64
+		want    int64
+		wanterr any
+	}{
+		{0, 4, 0, nil},
+		{1, 4, 4, nil},
+		{3, 4, 4, nil},
+		{4, 4, 4, nil},
+		{5, 2, 6, nil},
+		{7, 1, 7, nil},
+		{7, 3, 9, nil},
+		{6, 5, 10, nil},
+		{10, 5, 10, nil},
+		{9, 8, 9, io.ErrUnexpectedEOF},
+		{12, 4, 12, nil},
+		{13, 4, 13, io.ErrUnexpectedEOF},
+		{3, 0, 3, "reader.Reader.Align: non-positive alignment at offset 3"},
+		{3, -4, 3, "reader.Reader.Align: non-positive alignment at offset 3"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789"))
+		for _, tt := range tests {
+			if _, err := r.Seek(tt.off, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			skipped, err := r.Align(tt.n)
+			if fmt.Sprint(tt.wanterr) != fmt.Sprint(err) {
+				t.Errorf("Align(%d) at %d: error = %v; want %v", tt.n, tt.off, err, tt.wanterr)
+			}
+			if pos, _ := r.Seek(0, io.SeekCurrent); tt.want != pos || tt.want-tt.off != skipped {
+				t.Errorf("Align(%d) at %d: offset, skipped = %d, %d; want %d, %d", tt.n, tt.off, pos, skipped, tt.want, tt.want-tt.off)
+			}
+		}
+	})
+}
+
+func TestReaderAlignFrom(t *testing.T) {
+	t.Parallel()
+
+	testReaderAlignFrom[[]byte](t)
+	testReaderAlignFrom[string](t)
+}
+
+func testReaderAlignFrom[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		off, base, n int64
+		want         int64
+		wanterr      any
+	}{
+		{3, 3, 4, 3, nil},
+		{4, 3, 4, 7, nil},
+		{5, 1, 8, 9, nil},
+		{2, 5, 4, 5, nil},
+		{8, 3, 4, 8, io.ErrUnexpectedEOF},
+		{0, 0, 0, 0, "reader.Reader.AlignFrom: non-positive alignment at offset 0"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789"))
+		for _, tt := range tests {
+			if _, err := r.Seek(tt.off, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			skipped, err := r.AlignFrom(tt.base, tt.n)
+			if fmt.Sprint(tt.wanterr) != fmt.Sprint(err) {
+				t.Errorf("AlignFrom(%d, %d) at %d: error = %v; want %v", tt.base, tt.n, tt.off, err, tt.wanterr)
+			}
+			if pos, _ := r.Seek(0, io.SeekCurrent); tt.want != pos || tt.want-tt.off != skipped {
+				t.Errorf("AlignFrom(%d, %d) at %d: offset, skipped = %d, %d; want %d, %d", tt.base, tt.n, tt.off, pos, skipped, tt.want, tt.want-tt.off)
+			}
+		}
+	})
+}
+
+func TestReaderReadUvarint(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadUvarint[[]byte](t)
+	testReaderReadUvarint[string](t)
+}
+
+func testReaderReadUvarint[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	values := []uint64{0, 1, 127, 128, 300, 1<<32 - 1, 1 << 56, math.MaxUint64}
+	var buf []byte
+	for _, v := range values {
+		buf = binary.AppendUvarint(buf, v)
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(string(buf)))
+		for _, want := range values {
+			got, err := r.ReadUvarint()
+			if want != got || err != nil {
+				t.Errorf("ReadUvarint = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		if _, err := r.ReadUvarint(); err != io.EOF {
+			t.Errorf("at EOF: ReadUvarint error = %v; want EOF", err)
+		}
+	})
+}
+
+func TestReaderReadVarint(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadVarint[[]byte](t)
+	testReaderReadVarint[string](t)
+}
+
+func testReaderReadVarint[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	values := []int64{0, 1, -1, 63, -64, 64, -65, math.MaxInt64, math.MinInt64}
+	var buf []byte
+	for _, v := range values {
+		buf = binary.AppendVarint(buf, v)
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(string(buf)))
+		for _, want := range values {
+			got, err := r.ReadVarint()
+			if want != got || err != nil {
+				t.Errorf("ReadVarint = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+	})
+}
+
+func TestReaderReadUvarintErrors(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadUvarintErrors[[]byte](t)
+	testReaderReadUvarintErrors[string](t)
+}
+
+func testReaderReadUvarintErrors[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		wanterr string
+		unexp   bool
+	}{
+		{"x\x80", "reader.Reader.ReadUvarint: truncated varint: unexpected EOF at offset 1", true},
+		{"x\xff\xff", "reader.Reader.ReadUvarint: truncated varint: unexpected EOF at offset 1", true},
+		{"x\xff\xff\xff\xff\xff\xff\xff\xff\xff\x02", "reader.Reader.ReadUvarint: integer overflow at offset 1", false},
+		{"x\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01", "reader.Reader.ReadUvarint: integer overflow at offset 1", false},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			_, _ = r.ReadByte()
+			_, err := r.ReadUvarint()
+			if err == nil || tt.wanterr != err.Error() {
+				t.Errorf("ReadUvarint(%q) error = %v; want %s", tt.s, err, tt.wanterr)
+			}
+			if tt.unexp != errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("ReadUvarint(%q): errors.Is(err, io.ErrUnexpectedEOF) = %t", tt.s, !tt.unexp)
+			}
+			// The offset is left at the start of the malformed varint.
+			if r.Len() != len(tt.s)-1 {
+				t.Errorf("ReadUvarint(%q): Len = %d; want %d", tt.s, r.Len(), len(tt.s)-1)
+			}
+		}
+	})
+}
+
+func BenchmarkReaderReadUvarint(b *testing.B) {
+	var buf []byte
+	for i := 0; i < 1000; i++ {
+		buf = binary.AppendUvarint(buf, uint64(i)*uint64(i)*12345)
+	}
+	b.SetBytes(int64(len(buf)))
+	r := New(buf)
+	for i := 0; i < b.N; i++ {
+		r.Reset(buf)
+		for r.Len() > 0 {
+			_, _ = r.ReadUvarint()
+		}
+	}
+}
+
+func BenchmarkBinaryReadUvarint(b *testing.B) {
+	var buf []byte
+	for i := 0; i < 1000; i++ {
+		buf = binary.AppendUvarint(buf, uint64(i)*uint64(i)*12345)
+	}
+	b.SetBytes(int64(len(buf)))
+	r := New(buf)
+	for i := 0; i < b.N; i++ {
+		r.Reset(buf)
+		for r.Len() > 0 {
+			_, _ = binary.ReadUvarint(r)
+		}
+	}
+}
+
+func TestReaderReadPadded(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadPadded[[]byte](t)
+	testReaderReadPadded[string](t)
+}
+
+func testReaderReadPadded[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		n, align int
+		want     string
+		wanterr  any
+		wantlen  int
+	}{
+		{3, 2, "abc", nil, 6},
+		{4, 2, "abcd", nil, 6},
+		{1, 4, "a", nil, 6},
+		{0, 4, "", nil, 10},
+		{5, 8, "abcde", nil, 2},
+		{9, 4, "abcdefghi", nil, 0}, // missing padding at EOF
+		{11, 1, "", io.ErrUnexpectedEOF, 10},
+		{-1, 2, "", "reader.Reader.ReadPadded: negative count at offset 0", 10},
+		{2, 0, "", "reader.Reader.ReadPadded: non-positive alignment at offset 0", 10},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S("abcdefghij"))
+			b, err := r.ReadPadded(tt.n, tt.align)
+			if tt.want != string(b) || fmt.Sprint(tt.wanterr) != fmt.Sprint(err) {
+				t.Errorf("ReadPadded(%d, %d) = %q, %v; want %q, %v", tt.n, tt.align, b, err, tt.want, tt.wanterr)
+			}
+			if tt.wantlen != r.Len() {
+				t.Errorf("ReadPadded(%d, %d): Len = %d; want %d", tt.n, tt.align, r.Len(), tt.wantlen)
+			}
+		}
+	})
+}
+
+func TestReaderReadInts(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadInts[[]byte](t)
+	testReaderReadInts[string](t)
+}
+
+func testReaderReadInts[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const data = "\x01\x02\x03\x04\x05\x06\x07\x08\xff\xfe\xfd\xfc\xfb\xfa\xf9\xf8"
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			r := New(S(data))
+			b := []byte(data)
+			if v, err := r.ReadUint8(); v != b[0] || err != nil {
+				t.Errorf("ReadUint8 = %#x, %v; want %#x, nil", v, err, b[0])
+			}
+			if v, err := r.ReadUint16(order); v != order.Uint16(b[1:]) || err != nil {
+				t.Errorf("%v: ReadUint16 = %#x, %v; want %#x, nil", order, v, err, order.Uint16(b[1:]))
+			}
+			if v, err := r.ReadUint32(order); v != order.Uint32(b[3:]) || err != nil {
+				t.Errorf("%v: ReadUint32 = %#x, %v; want %#x, nil", order, v, err, order.Uint32(b[3:]))
+			}
+			if v, err := r.ReadUint64(order); v != order.Uint64(b[7:]) || err != nil {
+				t.Errorf("%v: ReadUint64 = %#x, %v; want %#x, nil", order, v, err, order.Uint64(b[7:]))
+			}
+
+			_, _ = r.Seek(8, io.SeekStart)
+			if v, err := r.ReadInt8(); v != -1 || err != nil {
+				t.Errorf("ReadInt8 = %d, %v; want -1, nil", v, err)
+			}
+			if v, err := r.ReadInt16(order); v != int16(order.Uint16(b[9:])) || err != nil {
+				t.Errorf("%v: ReadInt16 = %d, %v; want %d, nil", order, v, err, int16(order.Uint16(b[9:])))
+			}
+			if v, err := r.ReadInt32(order); v != int32(order.Uint32(b[11:])) || err != nil {
+				t.Errorf("%v: ReadInt32 = %d, %v; want %d, nil", order, v, err, int32(order.Uint32(b[11:])))
+			}
+			_, _ = r.Seek(8, io.SeekStart)
+			if v, err := r.ReadInt64(order); v != int64(order.Uint64(b[8:])) || err != nil {
+				t.Errorf("%v: ReadInt64 = %d, %v; want %d, nil", order, v, err, int64(order.Uint64(b[8:])))
+			}
+		}
+	})
+}
+
+func TestReaderReadUint24(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadUint24[[]byte](t)
+	testReaderReadUint24[string](t)
+}
+
+func testReaderReadUint24[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("\x01\x02\x03\x01\x02\x03\xff\xff\xffx"))
+		if v, err := r.ReadBigEndianUint24(); v != 0x010203 || err != nil {
+			t.Errorf("ReadBigEndianUint24 = %#x, %v; want 0x10203, nil", v, err)
+		}
+		if v, err := r.ReadLittleEndianUint24(); v != 0x030201 || err != nil {
+			t.Errorf("ReadLittleEndianUint24 = %#x, %v; want 0x30201, nil", v, err)
+		}
+		if v, err := r.ReadBigEndianUint24(); v != 1<<24-1 || err != nil {
+			t.Errorf("ReadBigEndianUint24 = %#x, %v; want 0xffffff, nil", v, err)
+		}
+		if r.Len() != 1 {
+			t.Errorf("Len = %d; want 1", r.Len())
+		}
+	})
+}
+
+func TestReaderReadInt24(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadInt24[[]byte](t)
+	testReaderReadInt24[string](t)
+}
+
+func testReaderReadInt24[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		be   string
+		want int32
+	}{
+		{"\x00\x00\x00", 0},
+		{"\x00\x00\x01", 1},
+		{"\x7f\xff\xff", 1<<23 - 1},
+		{"\x80\x00\x00", -1 << 23},
+		{"\xff\x80\x00", -0x8000},
+		{"\xff\xff\xff", -1},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			le := string([]byte{tt.be[2], tt.be[1], tt.be[0]})
+			r := New(S(tt.be + le))
+			if v, err := r.ReadBigEndianInt24(); v != tt.want || err != nil {
+				t.Errorf("ReadBigEndianInt24(%q) = %d, %v; want %d, nil", tt.be, v, err, tt.want)
+			}
+			if v, err := r.ReadLittleEndianInt24(); v != tt.want || err != nil {
+				t.Errorf("ReadLittleEndianInt24(%q) = %d, %v; want %d, nil", le, v, err, tt.want)
+			}
+		}
+	})
+}
+
+func TestReaderReadIntsTruncated(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadIntsTruncated[[]byte](t)
+	testReaderReadIntsTruncated[string](t)
+}
+
+func testReaderReadIntsTruncated[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	reads := []struct {
+		name  string
+		width int
+		read  func(*Reader[S]) error
+	}{
+		{"ReadUint8", 1, func(r *Reader[S]) error { _, err := r.ReadUint8(); return err }},
+		{"ReadUint16", 2, func(r *Reader[S]) error { _, err := r.ReadUint16(binary.BigEndian); return err }},
+		{"ReadBigEndianUint24", 3, func(r *Reader[S]) error { _, err := r.ReadBigEndianUint24(); return err }},
+		{"ReadLittleEndianUint24", 3, func(r *Reader[S]) error { _, err := r.ReadLittleEndianUint24(); return err }},
+		{"ReadUint32", 4, func(r *Reader[S]) error { _, err := r.ReadUint32(binary.LittleEndian); return err }},
+		{"ReadUint64", 8, func(r *Reader[S]) error { _, err := r.ReadUint64(binary.BigEndian); return err }},
+		{"ReadInt8", 1, func(r *Reader[S]) error { _, err := r.ReadInt8(); return err }},
+		{"ReadInt16", 2, func(r *Reader[S]) error { _, err := r.ReadInt16(binary.LittleEndian); return err }},
+		{"ReadBigEndianInt24", 3, func(r *Reader[S]) error { _, err := r.ReadBigEndianInt24(); return err }},
+		{"ReadLittleEndianInt24", 3, func(r *Reader[S]) error { _, err := r.ReadLittleEndianInt24(); return err }},
+		{"ReadInt32", 4, func(r *Reader[S]) error { _, err := r.ReadInt32(binary.BigEndian); return err }},
+		{"ReadInt64", 8, func(r *Reader[S]) error { _, err := r.ReadInt64(binary.LittleEndian); return err }},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, rd := range reads {
+			for n := 0; n < rd.width; n++ {
+				r := New(S("xxxxxxxxx"[:n]))
+				if err := rd.read(r); err != io.ErrUnexpectedEOF {
+					t.Errorf("%s with %d bytes: error = %v; want ErrUnexpectedEOF", rd.name, n, err)
+				}
+				if r.Len() != n {
+					t.Errorf("%s with %d bytes: Len = %d; want %d", rd.name, n, r.Len(), n)
+				}
+			}
+		}
+	})
+}
+
+func TestBinaryReader(t *testing.T) {
+	t.Parallel()
+
+	var buf []byte
+	buf = append(buf, 0xfe)
+	buf = binary.BigEndian.AppendUint16(buf, 0xfffe)
+	buf = binary.BigEndian.AppendUint32(buf, 0x01020304)
+	buf = binary.BigEndian.AppendUint64(buf, 0x0102030405060708)
+	buf = binary.BigEndian.AppendUint32(buf, math.Float32bits(1.5))
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(-2.25))
+
+	r := NewBinaryReader(string(buf), binary.BigEndian)
+	if r.ByteOrder() != binary.BigEndian {
+		t.Errorf("ByteOrder = %v; want BigEndian", r.ByteOrder())
+	}
+	if v, err := r.ReadInt8(); v != -2 || err != nil {
+		t.Errorf("ReadInt8 = %d, %v; want -2, nil", v, err)
+	}
+	if v, err := r.ReadInt16(); v != -2 || err != nil {
+		t.Errorf("ReadInt16 = %d, %v; want -2, nil", v, err)
+	}
+	if v, err := r.ReadUint32(); v != 0x01020304 || err != nil {
+		t.Errorf("ReadUint32 = %#x, %v; want 0x01020304, nil", v, err)
+	}
+	if v, err := r.ReadUint64(); v != 0x0102030405060708 || err != nil {
+		t.Errorf("ReadUint64 = %#x, %v; want 0x0102030405060708, nil", v, err)
+	}
+	if v, err := r.ReadFloat32(); v != 1.5 || err != nil {
+		t.Errorf("ReadFloat32 = %v, %v; want 1.5, nil", v, err)
+	}
+	if v, err := r.ReadFloat64(); v != -2.25 || err != nil {
+		t.Errorf("ReadFloat64 = %v, %v; want -2.25, nil", v, err)
+	}
+	if _, err := r.ReadFloat64(); err != io.ErrUnexpectedEOF {
+		t.Errorf("at EOF: ReadFloat64 error = %v; want ErrUnexpectedEOF", err)
+	}
+
+	le := NewBinaryReader([]byte{0x01, 0x02, 0x03, 0x04}, binary.LittleEndian)
+	if v, err := le.ReadUint16(); v != 0x0201 || err != nil {
+		t.Errorf("LittleEndian ReadUint16 = %#x, %v; want 0x0201, nil", v, err)
+	}
+	if v, err := le.ReadInt32(); v != 0 || err != io.ErrUnexpectedEOF {
+		t.Errorf("LittleEndian ReadInt32 = %d, %v; want 0, ErrUnexpectedEOF", v, err)
+	}
+	if v, err := le.ReadInt64(); v != 0 || err != io.ErrUnexpectedEOF {
+		t.Errorf("LittleEndian ReadInt64 = %d, %v; want 0, ErrUnexpectedEOF", v, err)
+	}
+	if le.Len() != 2 {
+		t.Errorf("Len = %d; want 2", le.Len())
+	}
+}
+
+func TestReaderReadFloats(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadFloats[[]byte](t)
+	testReaderReadFloats[string](t)
+}
+
+func testReaderReadFloats[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	bits32 := []uint32{
+		math.Float32bits(0), math.Float32bits(-1.25), math.Float32bits(float32(math.Inf(1))),
+		0x7f800001, // signaling NaN
+		0xffbfffff, // signaling NaN with sign bit and full payload
+		0x7fc00000, // quiet NaN
+	}
+	bits64 := []uint64{
+		math.Float64bits(math.Pi), math.Float64bits(math.SmallestNonzeroFloat64),
+		0x7ff0000000000001, // signaling NaN
+		0xfff7ffffffffffff, // signaling NaN with sign bit and full payload
+		0x7ff8000000000000, // quiet NaN
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, order := range []interface {
+			binary.ByteOrder
+			binary.AppendByteOrder
+		}{binary.BigEndian, binary.LittleEndian} {
+			var buf []byte
+			for _, v := range bits32 {
+				buf = order.AppendUint32(buf, v)
+			}
+			for _, v := range bits64 {
+				buf = order.AppendUint64(buf, v)
+			}
+
+			r := New(S(string(buf)))
+			for _, want := range bits32 {
+				v, err := r.ReadFloat32(order)
+				if got := math.Float32bits(v); want != got || err != nil {
+					t.Errorf("%v: ReadFloat32 bits = %#x, %v; want %#x, nil", order, got, err, want)
+				}
+			}
+			for _, want := range bits64 {
+				v, err := r.ReadFloat64(order)
+				if got := math.Float64bits(v); want != got || err != nil {
+					t.Errorf("%v: ReadFloat64 bits = %#x, %v; want %#x, nil", order, got, err, want)
+				}
+			}
+		}
+
+		r := New(S("1234567"))
+		if _, err := r.ReadFloat64(binary.BigEndian); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadFloat64 with 7 bytes: error = %v; want ErrUnexpectedEOF", err)
+		}
+		_, _ = r.Seek(4, io.SeekStart)
+		if _, err := r.ReadFloat32(binary.BigEndian); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadFloat32 with 3 bytes: error = %v; want ErrUnexpectedEOF", err)
+		}
+		if r.Len() != 3 {
+			t.Errorf("Len = %d; want 3", r.Len())
+		}
+	})
+}
+
+func TestReaderReadVariableField(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadVariableField[[]byte](t)
+	testReaderReadVariableField[string](t)
+}
+
+func testReaderReadVariableField[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		lengths []int
+		want    []string
+		wanterr any
+		wantlen int
+	}{
+		{nil, []string{}, nil, 10},
+		{[]int{2, 3, 1}, []string{"ab", "cde", "f"}, nil, 4},
+		{[]int{4, 0}, []string{"abcd", "efghij"}, nil, 0},
+		{[]int{0, 2}, []string{"", "ab"}, nil, 8},
+		{[]int{10, 0}, []string{"abcdefghij", ""}, nil, 0},
+		{[]int{5, 6}, nil, io.ErrUnexpectedEOF, 10},
+		{[]int{11, 0}, nil, io.ErrUnexpectedEOF, 10},
+		{[]int{1, math.MaxInt}, nil, io.ErrUnexpectedEOF, 10},
+		{[]int{math.MaxInt, math.MaxInt, 0}, nil, io.ErrUnexpectedEOF, 10},
+		{[]int{1, -1}, nil, "reader.Reader.ReadVariableField: negative count at offset 0", 10},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S("abcdefghij"))
+			fields, err := r.ReadVariableField(tt.lengths)
+			got := []string{}
+			for _, f := range fields {
+				got = append(got, string(f))
+			}
+			if fields == nil {
+				got = nil
+			}
+			if fmt.Sprintf("%q", tt.want) != fmt.Sprintf("%q", got) || fmt.Sprint(tt.wanterr) != fmt.Sprint(err) {
+				t.Errorf("ReadVariableField(%v) = %q, %v; want %q, %v", tt.lengths, got, err, tt.want, tt.wanterr)
+			}
+			if tt.wantlen != r.Len() {
+				t.Errorf("ReadVariableField(%v): Len = %d; want %d", tt.lengths, r.Len(), tt.wantlen)
+			}
+		}
+	})
+}
+
+type binaryHeader struct {
+	Magic   [4]byte
+	Version uint16
+	Flags   int8
+	_       [1]byte
+	Entries [3]struct {
+		Offset uint32
+		Size   int64
+		Ratio  float32
+	}
+	Checksum uint64
+}
+
+func TestReaderReadBinary(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBinary[[]byte](t)
+	testReaderReadBinary[string](t)
+}
+
+func testReaderReadBinary[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	var want binaryHeader
+	copy(want.Magic[:], "HDR1")
+	want.Version = 7
+	want.Flags = -3
+	for i := range want.Entries {
+		want.Entries[i].Offset = uint32(100 * i)
+		want.Entries[i].Size = int64(-i)
+		want.Entries[i].Ratio = float32(i) / 2
+	}
+	want.Checksum = 0xdeadbeefcafe
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			var buf bytes.Buffer
+			if err := binary.Write(&buf, order, &want); err != nil {
+				t.Fatal(err)
+			}
+			buf.WriteString("tail")
+
+			var ref binaryHeader
+			if err := binary.Read(bytes.NewReader(buf.Bytes()), order, &ref); err != nil {
+				t.Fatal(err)
+			}
+
+			r := New(S(buf.String()))
+			var got binaryHeader
+			if err := r.ReadBinary(order, &got); err != nil {
+				t.Fatalf("%v: ReadBinary: %v", order, err)
+			}
+			if ref != got {
+				t.Errorf("%v: ReadBinary = %+v; want %+v", order, got, ref)
+			}
+			if r.Len() != 4 {
+				t.Errorf("%v: Len = %d; want 4", order, r.Len())
+			}
+
+			// Truncated data is reported without advancing.
+			var more binaryHeader
+			if err := r.ReadBinary(order, &more); err != io.ErrUnexpectedEOF {
+				t.Errorf("%v: truncated ReadBinary error = %v; want ErrUnexpectedEOF", order, err)
+			}
+			if r.Len() != 4 {
+				t.Errorf("%v: after truncated ReadBinary: Len = %d; want 4", order, r.Len())
+			}
+
+			vals := make([]uint16, 2)
+			if err := r.ReadBinary(order, vals); err != nil || r.Len() != 0 {
+				t.Errorf("%v: ReadBinary(slice) = %v, Len %d; want nil, 0", order, err, r.Len())
+			}
+		}
+
+		r := New(S("data"))
+		var s string
+		if err := r.ReadBinary(binary.BigEndian, &s); err == nil || r.Len() != 4 {
+			t.Errorf("ReadBinary(*string) = %v, Len %d; want error, 4", err, r.Len())
+		}
+	})
+}
+
+func TestReadStruct(t *testing.T) {
+	t.Parallel()
+
+	testReadStruct[[]byte](t)
+	testReadStruct[string](t)
+}
+
+func testReadStruct[S ~[]byte | ~string](t *testing.T) {
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		t.Parallel()
+
+		var want binaryHeader
+		copy(want.Magic[:], "HDR1")
+		want.Version = 7
+		want.Entries[2].Size = -1
+		want.Checksum = 0xdeadbeefcafe
+		b, err := binary.Append(nil, binary.LittleEndian, &want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := New(S(append(b, "tail"...)))
+		got, err := ReadStruct[binaryHeader](r, binary.LittleEndian)
+		if want != got || err != nil {
+			t.Errorf("ReadStruct = %+v, %v; want %+v, nil", got, err, want)
+		}
+		if v, err := ReadStruct[uint16](r, binary.BigEndian); v != 't'<<8|'a' || err != nil {
+			t.Errorf("ReadStruct[uint16] = %#x, %v; want %#x, nil", v, err, 't'<<8|'a')
+		}
+		if v, err := ReadStruct[uint32](r, binary.BigEndian); v != 0 || err != io.ErrUnexpectedEOF {
+			t.Errorf("truncated ReadStruct[uint32] = %#x, %v; want 0, unexpected EOF", v, err)
+		}
+		if r.Len() != 2 {
+			t.Errorf("Len after truncated ReadStruct = %d; want 2", r.Len())
+		}
+		if _, err := ReadStruct[string](r, binary.BigEndian); !errors.Is(err, ErrInvalidType) {
+			t.Errorf("ReadStruct[string] error = %v; want one wrapping %v", err, ErrInvalidType)
+		}
+	})
+}
+
+func TestReaderReadLEB128(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadLEB128[[]byte](t)
+	testReaderReadLEB128[string](t)
+}
+
+func testReaderReadLEB128[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	utests := []struct {
+		s    string
+		want uint64
+	}{
+		{"\x00", 0},
+		{"\x80\x80\x00", 0},
+		{"\x02", 2},
+		{"\x7f", 127},
+		{"\x80\x01", 128},
+		{"\xe5\x8e\x26", 624485},
+		{"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01", math.MaxUint64},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range utests {
+			r := New(S(tt.s))
+			got, err := r.ReadULEB128()
+			if tt.want != got || err != nil {
+				t.Errorf("ReadULEB128(%q) = %d, %v; want %d, nil", tt.s, got, err, tt.want)
+			}
+			if r.Len() != 0 {
+				t.Errorf("ReadULEB128(%q): Len = %d; want 0", tt.s, r.Len())
+			}
+		}
+
+		stests := []struct {
+			s    string
+			want int64
+		}{
+			{"\x00", 0},
+			{"\x80\x80\x00", 0},
+			{"\x7f", -1},
+			{"\xff\x7f", -1},
+			{"\x3f", 63},
+			{"\x40", -64},
+			{"\xc0\xbb\x78", -123456},
+			{"\x80\x80\x80\x80\x80\x80\x80\x80\x80\x7f", math.MinInt64},
+			{"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x00", math.MaxInt64},
+		}
+		for _, tt := range stests {
+			r := New(S(tt.s))
+			got, err := r.ReadSLEB128()
+			if tt.want != got || err != nil {
+				t.Errorf("ReadSLEB128(%q) = %d, %v; want %d, nil", tt.s, got, err, tt.want)
+			}
+			if r.Len() != 0 {
+				t.Errorf("ReadSLEB128(%q): Len = %d; want 0", tt.s, r.Len())
+			}
+		}
+
+		overflows := []struct {
+			s      string
+			signed bool
+		}{
+			{"\x80\x80\x80\x80\x80\x80\x80\x80\x80\x01", true}, // +2^63
+			{"\x80\x80\x80\x80\x80\x80\x80\x80\x80\x3f", true},
+			{"\x80\x80\x80\x80\x80\x80\x80\x80\x80\x02", false},
+			{"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x7f", false},
+		}
+		for _, tt := range overflows {
+			r := New(S(tt.s))
+			var err error
+			if tt.signed {
+				_, err = r.ReadSLEB128()
+			} else {
+				_, err = r.ReadULEB128()
+			}
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("signed %t: LEB128(%q) error = %v; want %v", tt.signed, tt.s, err, ErrOverflow)
+			}
+			if r.Len() != len(tt.s) {
+				t.Errorf("signed %t: LEB128(%q): Len = %d; want %d", tt.signed, tt.s, r.Len(), len(tt.s))
+			}
+		}
+
+		r := New(S(""))
+		if _, err := r.ReadULEB128(); err != io.EOF {
+			t.Errorf("at EOF: ReadULEB128 error = %v; want EOF", err)
+		}
+		if _, err := r.ReadSLEB128(); err != io.EOF {
+			t.Errorf("at EOF: ReadSLEB128 error = %v; want EOF", err)
+		}
+	})
+}
+
+func TestReaderReadLEB128Truncated(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadLEB128Truncated[[]byte](t)
+	testReaderReadLEB128Truncated[string](t)
+}
+
+func testReaderReadLEB128Truncated[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const s = "x\x80\x80\x80\x80\x80\x80\x80\x80\x80\x7f"
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for n := 2; n < len(s); n++ {
+			r := New(S(s[:n]))
+			_, _ = r.ReadByte()
+			if _, err := r.ReadSLEB128(); !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("ReadSLEB128(%q) error = %v; want unexpected EOF", s[1:n], err)
+			}
+			if _, err := r.ReadULEB128(); !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("ReadULEB128(%q) error = %v; want unexpected EOF", s[1:n], err)
+			}
+			if r.Len() != n-1 {
+				t.Errorf("ReadULEB128(%q): Len = %d; want %d", s[1:n], r.Len(), n-1)
+			}
+		}
+	})
+}
+
+func TestReaderReadLEB128Errors(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadLEB128Errors[[]byte](t)
+	testReaderReadLEB128Errors[string](t)
+}
+
+func testReaderReadLEB128Errors[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		signed  bool
+		max     int
+		wanterr string
+	}{
+		{"x\xff\xff\xff\xff\xff\xff\xff\xff\xff\x02", false, 10, "reader.Reader.ReadULEB128Max: integer overflow at offset 1"},
+		{"x\x80\x80\x80\x80\x80\x80\x80\x80\x80\x80\x01", false, 11, "reader.Reader.ReadULEB128Max: integer overflow at offset 1"},
+		{"x\x80\x80\x80\x80\x80\x80\x80\x80\x80\x80\x00", false, 10, "reader.Reader.ReadULEB128Max: LEB128 encoding exceeds maximum length of 10 bytes at offset 1"},
+		{"x\x80\x80\x00", false, 2, "reader.Reader.ReadULEB128Max: LEB128 encoding exceeds maximum length of 2 bytes at offset 1"},
+		{"x\x80", false, 0, "reader.Reader.ReadULEB128Max: invalid width 0 at offset 1"},
+		{"x\x80\x80\x80\x80\x80\x80\x80\x80\x80\x3f", true, 10, "reader.Reader.ReadSLEB128Max: integer overflow at offset 1"},
+		{"x\x80\x80\x80\x80\x80\x80\x80\x80\x80\xff\x00", true, 11, "reader.Reader.ReadSLEB128Max: integer overflow at offset 1"},
+		{"x\xff\x7f", true, 1, "reader.Reader.ReadSLEB128Max: LEB128 encoding exceeds maximum length of 1 bytes at offset 1"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			_, _ = r.ReadByte()
+			var err error
+			if tt.signed {
+				_, err = r.ReadSLEB128Max(tt.max)
+			} else {
+				_, err = r.ReadULEB128Max(tt.max)
+			}
+			if err == nil || tt.wanterr != err.Error() {
+				t.Errorf("%q: error = %v; want %s", tt.s, err, tt.wanterr)
+			}
+			// The offset is left at the start of the malformed value.
+			if r.Len() != len(tt.s)-1 {
+				t.Errorf("%q: Len = %d; want %d", tt.s, r.Len(), len(tt.s)-1)
+			}
+		}
+
+		// Redundant padding within the limit is accepted.
+		r := New(S("\xff\xff\xff\xff\xff\xff\xff\xff\xff\x7f\x7f\x7f"))
+		if got, err := r.ReadSLEB128Max(12); got != -1 || err != nil {
+			t.Errorf("ReadSLEB128Max(12) = %d, %v; want -1, nil", got, err)
+		}
+	})
+}
+
+func TestReaderReadCString(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadCString(t, []byte(".text\x00\x00.data\x00tail"))
+	testReaderReadCString(t, ".text\x00\x00.data\x00tail")
+}
+
+func testReaderReadCString[S ~[]byte | ~string](t *testing.T, data S) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+		r := New(data)
+		for _, want := range []string{".text", "", ".data"} {
+			got, err := r.ReadCString()
+			if want != string(got) || err != nil {
+				t.Errorf("ReadCString = %q, %v; want %q, nil", got, err, want)
+			}
+		}
+
+		// An unterminated string is returned but not consumed.
+		got, err := r.ReadCString()
+		if string(got) != "tail" || err != io.ErrUnexpectedEOF {
+			t.Errorf("unterminated: ReadCString = %q, %v; want %q, unexpected EOF", got, err, "tail")
+		}
+		if r.Len() != len("tail") {
+			t.Errorf("unterminated: Len = %d; want %d", r.Len(), len("tail"))
+		}
+
+		r.Seek(0, io.SeekEnd)
+		if _, err := r.ReadCString(); err != io.EOF {
+			t.Errorf("at EOF: ReadCString error = %v; want EOF", err)
+		}
+	})
+}
+
+func TestReaderReadCStringMax(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadCStringMax[[]byte](t)
+	testReaderReadCStringMax[string](t)
+}
+
+func testReaderReadCStringMax[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		n       int
+		want    string
+		wanterr string
+	}{
+		{"abc\x00", 3, "abc", ""},
+		{"abc\x00", 10, "abc", ""},
+		{"\x00", 0, "", ""},
+		{"abc\x00", 2, "", "reader.Reader.ReadCStringMax: string exceeds maximum length of 2 bytes at offset 0"},
+		{"abcdef", 3, "", "reader.Reader.ReadCStringMax: string exceeds maximum length of 3 bytes at offset 0"},
+		{"abc", 3, "abc", "unexpected EOF"},
+		{"abc\x00", -1, "", "reader.Reader.ReadCStringMax: negative count at offset 0"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ReadCStringMax(tt.n)
+			if tt.wanterr == "" {
+				if tt.want != string(got) || err != nil {
+					t.Errorf("ReadCStringMax(%q, %d) = %q, %v; want %q, nil", tt.s, tt.n, got, err, tt.want)
+				}
+				continue
+			}
+			if tt.want != string(got) || err == nil || tt.wanterr != err.Error() {
+				t.Errorf("ReadCStringMax(%q, %d) = %q, %v; want %q, %s", tt.s, tt.n, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != len(tt.s) {
+				t.Errorf("ReadCStringMax(%q, %d): Len = %d; want %d", tt.s, tt.n, r.Len(), len(tt.s))
+			}
+		}
+	})
+}
+
+func TestReaderReadNullPaddedString(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadNullPaddedString[[]byte](t)
+	testReaderReadNullPaddedString[string](t)
+}
+
+func testReaderReadNullPaddedString[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		n       int
+		want    string
+		wanterr error
+		rest    int
+	}{
+		{"KERNEL  SYS", 8, "KERNEL  ", nil, 3},
+		{"abc\x00\x00\x00de", 6, "abc", nil, 2},
+		{"abc\x00", 4, "abc", nil, 0},
+		{"abcd", 4, "abcd", nil, 0},
+		{"a\x00b\x00", 4, "a\x00b", nil, 0},
+		{"\x00\x00\x00\x00x", 4, "", nil, 1},
+		{"", 0, "", nil, 0},
+		{"abc", 4, "", io.ErrUnexpectedEOF, 3},
+		{"abc", -1, "", ErrNegativeCount, 3},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ReadNullPaddedString(tt.n)
+			if tt.want != got || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ReadNullPaddedString(%q, %d) = %q, %v; want %q, %v", tt.s, tt.n, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != tt.rest {
+				t.Errorf("ReadNullPaddedString(%q, %d): Len = %d; want %d", tt.s, tt.n, r.Len(), tt.rest)
+			}
+		}
+	})
+}
+
+func TestReaderReadLenPrefixed(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadLenPrefixed[[]byte](t)
+	testReaderReadLenPrefixed[string](t)
+}
+
+func testReaderReadLenPrefixed[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	type appendByteOrder interface {
+		binary.ByteOrder
+		binary.AppendByteOrder
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, order := range []appendByteOrder{binary.BigEndian, binary.LittleEndian} {
+			for _, width := range []int{1, 2, 4, 8} {
+				var buf []byte
+				for _, v := range []string{"pascal", "", "x"} {
+					switch width {
+					case 1:
+						buf = append(buf, byte(len(v)))
+					case 2:
+						buf = order.AppendUint16(buf, uint16(len(v)))
+					case 4:
+						buf = order.AppendUint32(buf, uint32(len(v)))
+					case 8:
+						buf = order.AppendUint64(buf, uint64(len(v)))
+					}
+					buf = append(buf, v...)
+				}
+
+				r := New(S(buf))
+				for _, want := range []string{"pascal", "", "x"} {
+					got, err := r.ReadLenPrefixed(width, order)
+					if want != string(got) || err != nil {
+						t.Errorf("%v: ReadLenPrefixed(%d) = %q, %v; want %q, nil", order, width, got, err, want)
+					}
+				}
+				if _, err := r.ReadLenPrefixed(width, order); err != io.ErrUnexpectedEOF {
+					t.Errorf("%v: at EOF: ReadLenPrefixed(%d) error = %v; want unexpected EOF", order, width, err)
+				}
+			}
+		}
+
+		for _, tt := range []struct {
+			s     string
+			width int
+		}{
+			{"\x07abc", 1},
+			{"\x00", 2},
+			{"\xff\xff\xff\xffabc", 4},
+			{"\xff\xff\xff\xff\xff\xff\xff\xffabc", 8},
+			{"\x80\x00\x00\x00\x00\x00\x00\x00abc", 8},
+			{"\x00\x00\x00\x01\x00\x00\x00\x00abc", 8},
+		} {
+			r := New(S(tt.s))
+			got, err := r.ReadLenPrefixed(tt.width, binary.BigEndian)
+			if len(got) != 0 || err != io.ErrUnexpectedEOF {
+				t.Errorf("ReadLenPrefixed(%q, %d) = %q, %v; want \"\", unexpected EOF", tt.s, tt.width, got, err)
+			}
+			if r.Len() != len(tt.s) {
+				t.Errorf("ReadLenPrefixed(%q, %d): Len = %d; want %d", tt.s, tt.width, r.Len(), len(tt.s))
+			}
+		}
+
+		r := New(S("\x01a"))
+		if _, err := r.ReadLenPrefixed(3, binary.BigEndian); err == nil || err.Error() != "reader.Reader.ReadLenPrefixed: invalid width 3 at offset 0" {
+			t.Errorf("ReadLenPrefixed(3) error = %v", err)
+		}
+		if got, err := r.ReadLenPrefixed(1, nil); string(got) != "a" || err != nil {
+			t.Errorf("ReadLenPrefixed(1, nil) = %q, %v; want %q, nil", got, err, "a")
+		}
+	})
+}
+
+func TestReaderReadAddresses(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadAddresses[[]byte](t)
+	testReaderReadAddresses[string](t)
+
+	// The results must not alias the underlying data.
+	data := []byte{10, 0, 0, 1}
+	ip, _ := New(data).ReadIPv4()
+	data[0] = 11
+	if ip[0] != 10 {
+		t.Errorf("ReadIPv4 result aliases the data: %v", ip)
+	}
+}
+
+func testReaderReadAddresses[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("\xc0\xa8\x01\xfe\x00\x1a\x2b\x3c\x4d\x5e\x01\x02\x03"))
+		ip, err := r.ReadIPv4()
+		if !ip.Equal(net.IPv4(192, 168, 1, 254)) || err != nil {
+			t.Errorf("ReadIPv4 = %v, %v; want 192.168.1.254, nil", ip, err)
+		}
+		mac, err := r.ReadMACAddress()
+		if mac.String() != "00:1a:2b:3c:4d:5e" || err != nil {
+			t.Errorf("ReadMACAddress = %v, %v; want 00:1a:2b:3c:4d:5e, nil", mac, err)
+		}
+		if ip, err := r.ReadIPv4(); ip != nil || err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadIPv4 = %v, %v; want nil, unexpected EOF", ip, err)
+		}
+		if mac, err := r.ReadMACAddress(); mac != nil || err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadMACAddress = %v, %v; want nil, unexpected EOF", mac, err)
+		}
+		if r.Len() != 3 {
+			t.Errorf("truncated reads advanced the Reader: Len = %d; want 3", r.Len())
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+)
+
+// A BitOrder specifies how bits are packed into bytes for a BitReader.
+type BitOrder int
+
+const (
+	// LSBFirst packs bits starting at the least significant bit of each
+	// byte, as in DEFLATE.
+	LSBFirst BitOrder = iota
+	// MSBFirst packs bits starting at the most significant bit of each
+	// byte, as in many sensor formats and codecs.
+	MSBFirst
+)
+
+// A BitReader reads bit fields from the data of a Reader.
+// It shares the offset of its Reader: once all bits of a byte have been
+// read, the Reader is advanced past it. After AlignByte, the byte-level
+// methods of the Reader continue where the BitReader stopped; other uses
+// of the Reader while a byte is partially read are undefined.
+type BitReader[S ~[]byte | ~string] struct {
+	r     *Reader[S]
+	order BitOrder
+	nbits uint // number of bits of r.s[r.off] already read
+}
+
+// Bits returns a BitReader reading from the current position of r,
+// with bits packed in the given order.
+func (r *Reader[S]) Bits(order BitOrder) *BitReader[S] {
+	return &BitReader[S]{r: r, order: order}
+}
+
+// ReadBits reads the next n bits, at most 64, and returns them as the
+// low bits of the result. With LSBFirst, the first bit read is the least
+// significant bit of the result; with MSBFirst, the most significant.
+// If fewer than n bits remain, ReadBits returns io.ErrUnexpectedEOF and
+// reads nothing. It returns an error if n is greater than 64.
+func (b *BitReader[S]) ReadBits(n uint) (uint64, error) {
+	r := b.r
+	r.lastRead = opInvalid
+	if n > 64 {
+		return 0, &ReaderError{Method: "BitReader.ReadBits", Offset: r.off, Cause: fmt.Errorf("%w %d", ErrInvalidWidth, n)}
+	}
+	if remain := r.end() - r.off; remain <= 0 || uint64(remain)*8-uint64(b.nbits) < uint64(n) {
+		if n == 0 {
+			return 0, nil
+		}
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	var v uint64
+	for got := uint(0); got < n; {
+		c := r.s[r.off]
+		take := min(8-b.nbits, n-got)
+		mask := byte(1)<<take - 1
+		if b.order == LSBFirst {
+			v |= uint64(c>>b.nbits&mask) << got
+		} else {
+			v = v<<take | uint64(c>>(8-b.nbits-take)&mask)
+		}
+		got += take
+		if b.nbits += take; b.nbits == 8 {
+			r.off++
+			b.nbits = 0
+		}
+	}
+	return v, nil
+}
+
+// ReadBit reads the next bit. If no bits remain, it returns
+// io.ErrUnexpectedEOF.
+func (b *BitReader[S]) ReadBit() (uint8, error) {
+	v, err := b.ReadBits(1)
+	return uint8(v), err
+}
+
+// AlignByte discards the unread bits of a partially read byte, so that
+// the Reader is positioned at the next whole byte, and returns the
+// number of bits discarded.
+func (b *BitReader[S]) AlignByte() int {
+	if b.nbits == 0 {
+		return 0
+	}
+	n := int(8 - b.nbits)
+	b.r.off++
+	b.nbits = 0
+	return n
+}
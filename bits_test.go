@@ -0,0 +1,136 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+// bitWriter is a reference bit packer, writing one bit at a time.
+type bitWriter struct {
+	order BitOrder
+	buf   []byte
+	nbits uint // total bits written
+}
+
+func (w *bitWriter) writeBits(v uint64, n uint) {
+	for i := uint(0); i < n; i++ {
+		var bit uint64
+		if w.order == LSBFirst {
+			bit = v >> i & 1
+		} else {
+			bit = v >> (n - 1 - i) & 1
+		}
+		if w.nbits%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		pos := w.nbits % 8
+		if w.order == MSBFirst {
+			pos = 7 - pos
+		}
+		w.buf[len(w.buf)-1] |= byte(bit) << pos
+		w.nbits++
+	}
+}
+
+func TestBitReaderRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(1))
+	for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+		type field struct {
+			v uint64
+			n uint
+		}
+		var fields []field
+		w := &bitWriter{order: order}
+		for range 500 {
+			n := uint(rng.Intn(65))
+			v := rng.Uint64()
+			if n < 64 {
+				v &= 1<<n - 1
+			}
+			fields = append(fields, field{v, n})
+			w.writeBits(v, n)
+		}
+
+		testReader(t, string(w.buf), func(t *testing.T, r readerInterface) {
+			var b interface {
+				ReadBits(n uint) (uint64, error)
+			}
+			switch r := r.(type) {
+			case *Reader[[]byte]:
+				b = r.Bits(order)
+			case *Reader[string]:
+				b = r.Bits(order)
+			}
+			for i, f := range fields {
+				v, err := b.ReadBits(f.n)
+				if f.v != v || err != nil {
+					t.Fatalf("order %d: field %d: ReadBits(%d) = %#x, %v; want %#x, nil", order, i, f.n, v, err, f.v)
+				}
+			}
+		})
+	}
+}
+
+func TestBitReader(t *testing.T) {
+	t.Parallel()
+
+	testBitReader[[]byte](t)
+	testBitReader[string](t)
+}
+
+func testBitReader[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		// A DEFLATE block header: BFINAL=1, BTYPE=01 (fixed Huffman).
+		r := New(S("\x03\xffAB"))
+		b := r.Bits(LSBFirst)
+		if bit, err := b.ReadBit(); bit != 1 || err != nil {
+			t.Errorf("BFINAL = %d, %v; want 1, nil", bit, err)
+		}
+		if v, err := b.ReadBits(2); v != 1 || err != nil {
+			t.Errorf("BTYPE = %d, %v; want 1, nil", v, err)
+		}
+		if n := b.AlignByte(); n != 5 {
+			t.Errorf("AlignByte = %d; want 5", n)
+		}
+		if n := b.AlignByte(); n != 0 {
+			t.Errorf("second AlignByte = %d; want 0", n)
+		}
+
+		r.Seek(1, io.SeekStart)
+		b = r.Bits(MSBFirst)
+		if v, err := b.ReadBits(4); v != 0xf || err != nil {
+			t.Errorf("ReadBits(4) = %#x, %v; want 0xf, nil", v, err)
+		}
+		// Reading past the end fails without consuming.
+		if _, err := b.ReadBits(21); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadBits(21) error = %v; want unexpected EOF", err)
+		}
+		if v, err := b.ReadBits(12); v != 0xf41 || err != nil {
+			t.Errorf("ReadBits(12) = %#x, %v; want 0xf41, nil", v, err)
+		}
+		// Byte-level reads resume after AlignByte.
+		b.AlignByte()
+		if c, err := r.ReadByte(); c != 'B' || err != nil {
+			t.Errorf("ReadByte after AlignByte = %q, %v; want 'B', nil", c, err)
+		}
+		if _, err := b.ReadBit(); err != io.ErrUnexpectedEOF {
+			t.Errorf("at EOF: ReadBit error = %v; want unexpected EOF", err)
+		}
+		if v, err := b.ReadBits(0); v != 0 || err != nil {
+			t.Errorf("at EOF: ReadBits(0) = %d, %v; want 0, nil", v, err)
+		}
+		var e *ReaderError
+		if _, err := b.ReadBits(65); !errors.As(err, &e) || e.Method != "BitReader.ReadBits" || !errors.Is(err, ErrInvalidWidth) {
+			t.Errorf("ReadBits(65) error = %v; want *ReaderError from BitReader.ReadBits wrapping %v", err, ErrInvalidWidth)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package reader
+
+import (
+	"strconv"
+	"strings"
+)
+
+// An Encoding is a Unicode encoding identified by a byte order mark.
+type Encoding int
+
+const (
+	EncodingUnknown Encoding = iota // no byte order mark
+	EncodingUTF8
+	EncodingUTF16LE
+	EncodingUTF16BE
+	EncodingUTF32LE
+	EncodingUTF32BE
+)
+
+var encodingNames = [...]string{
+	EncodingUnknown: "unknown",
+	EncodingUTF8:    "UTF-8",
+	EncodingUTF16LE: "UTF-16LE",
+	EncodingUTF16BE: "UTF-16BE",
+	EncodingUTF32LE: "UTF-32LE",
+	EncodingUTF32BE: "UTF-32BE",
+}
+
+func (e Encoding) String() string {
+	if e < 0 || int(e) >= len(encodingNames) {
+		return "Encoding(" + strconv.Itoa(int(e)) + ")"
+	}
+	return encodingNames[e]
+}
+
+// boms lists the byte order marks, longest first so that the UTF-32LE
+// mark is not taken for the UTF-16LE one it starts with.
+var boms = [...]struct {
+	mark string
+	enc  Encoding
+}{
+	{"\xff\xfe\x00\x00", EncodingUTF32LE},
+	{"\x00\x00\xfe\xff", EncodingUTF32BE},
+	{"\xef\xbb\xbf", EncodingUTF8},
+	{"\xff\xfe", EncodingUTF16LE},
+	{"\xfe\xff", EncodingUTF16BE},
+}
+
+// DetectBOM reports the encoding indicated by a byte order mark at the
+// current position, and the length of the mark, without advancing the
+// Reader. If there is no byte order mark, it returns EncodingUnknown, 0.
+// Data starting with "\xff\xfe\x00\x00" is taken to be UTF-32LE, although
+// it could also be UTF-16LE beginning with a NUL character.
+func (r *Reader[S]) DetectBOM() (Encoding, int) {
+	s := asString(r.unread())
+	for _, b := range boms {
+		if strings.HasPrefix(s, b.mark) {
+			return b.enc, len(b.mark)
+		}
+	}
+	return EncodingUnknown, 0
+}
+
+// SkipBOM advances the Reader past a UTF-8 byte order mark,
+// "\xef\xbb\xbf", at the current position, usually the start of the
+// data, and reports whether there was one.
+func (r *Reader[S]) SkipBOM() (skipped bool) {
+	r.lastRead = opInvalid
+	if !strings.HasPrefix(asString(r.unread()), "\xef\xbb\xbf") {
+		return false
+	}
+	r.off += 3
+	return true
+}
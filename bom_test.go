@@ -0,0 +1,90 @@
+package reader_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderDetectBOM(t *testing.T) {
+	t.Parallel()
+
+	testReaderDetectBOM[[]byte](t)
+	testReaderDetectBOM[string](t)
+}
+
+func testReaderDetectBOM[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s   string
+		enc Encoding
+		n   int
+	}{
+		{"\xef\xbb\xbfhello", EncodingUTF8, 3},
+		{"\xef\xbb\xbf", EncodingUTF8, 3},
+		{"\xff\xfeh\x00", EncodingUTF16LE, 2},
+		{"\xfe\xff\x00h", EncodingUTF16BE, 2},
+		{"\xff\xfe\x00\x00h\x00\x00\x00", EncodingUTF32LE, 4},
+		{"\x00\x00\xfe\xff\x00\x00\x00h", EncodingUTF32BE, 4},
+		{"\xef\xbb", EncodingUnknown, 0},
+		{"\xef\xbbx", EncodingUnknown, 0},
+		{"\x00\x00\xfe", EncodingUnknown, 0},
+		{"hello", EncodingUnknown, 0},
+		{"", EncodingUnknown, 0},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			enc, n := r.DetectBOM()
+			if tt.enc != enc || tt.n != n {
+				t.Errorf("DetectBOM(%q) = %v, %d; want %v, %d", tt.s, enc, n, tt.enc, tt.n)
+			}
+			if r.Len() != len(tt.s) {
+				t.Errorf("DetectBOM(%q) advanced the Reader", tt.s)
+			}
+		}
+
+		if s := Encoding(42).String(); s != "Encoding(42)" {
+			t.Errorf("Encoding(42).String() = %q", s)
+		}
+	})
+}
+
+func TestReaderSkipBOM(t *testing.T) {
+	t.Parallel()
+
+	testReaderSkipBOM[[]byte](t)
+	testReaderSkipBOM[string](t)
+}
+
+func testReaderSkipBOM[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		skipped bool
+		wantLen int
+	}{
+		{"\xef\xbb\xbfhello", true, 5},
+		{"\xef\xbb\xbf", true, 0},
+		{"\xef\xbb", false, 2},
+		{"\xef\xbbx", false, 3},
+		{"\xff\xfeh\x00", false, 4},
+		{"", false, 0},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			if skipped := r.SkipBOM(); tt.skipped != skipped || r.Len() != tt.wantLen {
+				t.Errorf("SkipBOM(%q) = %t with Len %d; want %t with Len %d", tt.s, skipped, r.Len(), tt.skipped, tt.wantLen)
+			}
+			if r.SkipBOM() {
+				t.Errorf("second SkipBOM(%q) = true; want false", tt.s)
+			}
+		}
+	})
+}
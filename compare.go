@@ -0,0 +1,114 @@
+package reader
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EqualBytes reports whether the unread portion of the slice or string
+// is equal to p. EqualBytes does not modify the Reader.
+func (r *Reader[S]) EqualBytes(p []byte) bool {
+	return asString(r.unread()) == asString(p)
+}
+
+// EqualString reports whether the unread portion of the slice or string
+// is equal to s. EqualString does not modify the Reader.
+func (r *Reader[S]) EqualString(s string) bool {
+	return asString(r.unread()) == s
+}
+
+// HasPrefix reports whether the unread portion of the slice or string
+// begins with p. HasPrefix does not modify the Reader.
+func (r *Reader[S]) HasPrefix(p S) bool { return r.HasPrefixString(asString(p)) }
+
+// HasPrefixString is like HasPrefix but takes a string, so that a
+// Reader[[]byte] can be probed with a string constant without a
+// conversion.
+func (r *Reader[S]) HasPrefixString(s string) bool {
+	return strings.HasPrefix(asString(r.unread()), s)
+}
+
+// HasSuffix reports whether the unread portion of the slice or string
+// ends with p. HasSuffix does not modify the Reader.
+func (r *Reader[S]) HasSuffix(p S) bool { return r.HasSuffixString(asString(p)) }
+
+// HasSuffixString is like HasSuffix but takes a string.
+func (r *Reader[S]) HasSuffixString(s string) bool {
+	return strings.HasSuffix(asString(r.unread()), s)
+}
+
+// MatchAt reports whether pattern occurs at offset off from the start of
+// the underlying data, regardless of the current position. A pattern
+// that would extend outside the data, or a negative offset, does not
+// match. MatchAt does not modify the Reader, not even for UnreadRune.
+func (r *Reader[S]) MatchAt(off int64, pattern []byte) bool {
+	if off < 0 || off > int64(len(r.s)) || int64(len(pattern)) > int64(len(r.s))-off {
+		return false
+	}
+	return asString(r.s[off:off+int64(len(pattern))]) == asString(pattern)
+}
+
+// HasPrefixFold reports whether the unread data begins with prefix under
+// Unicode simple case folding, as strings.EqualFold compares, so that
+// "Content-Length:" matches "content-length:".
+// HasPrefixFold does not modify the Reader.
+func (r *Reader[S]) HasPrefixFold(prefix string) bool {
+	_, ok := prefixFoldLen(asString(r.unread()), prefix)
+	return ok
+}
+
+// ExpectFold is like HasPrefixFold but on a match advances the Reader past
+// the matched data and returns true. Since case folding can pair runes of
+// different encoded lengths, such as the Kelvin sign and 'k', the number
+// of bytes consumed need not be len(prefix).
+// On a mismatch the Reader is not advanced.
+func (r *Reader[S]) ExpectFold(prefix string) bool {
+	r.lastRead = opInvalid
+	n, ok := prefixFoldLen(asString(r.unread()), prefix)
+	if ok {
+		r.off += int64(n)
+	}
+	return ok
+}
+
+// prefixFoldLen reports whether s begins with prefix under simple case
+// folding and, if so, the length in bytes of the matching part of s.
+func prefixFoldLen(s, prefix string) (int, bool) {
+	i := 0
+	for _, pr := range prefix {
+		if i >= len(s) {
+			return 0, false
+		}
+		sr, size := rune(s[i]), 1
+		if sr >= utf8.RuneSelf {
+			sr, size = utf8.DecodeRuneInString(s[i:])
+		}
+		i += size
+		if sr == pr {
+			continue
+		}
+
+		// Make sr < pr to simplify what follows.
+		if pr < sr {
+			sr, pr = pr, sr
+		}
+		// Fast check for ASCII.
+		if pr < utf8.RuneSelf {
+			if 'A' <= sr && sr <= 'Z' && pr == sr+'a'-'A' {
+				continue
+			}
+			return 0, false
+		}
+		// General case: SimpleFold(x) returns the next equivalent rune > x
+		// or wraps around to smaller values.
+		f := unicode.SimpleFold(sr)
+		for f != sr && f < pr {
+			f = unicode.SimpleFold(f)
+		}
+		if f != pr {
+			return 0, false
+		}
+	}
+	return i, true
+}
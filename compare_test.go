@@ -0,0 +1,211 @@
+package reader_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderEqual(t *testing.T) {
+	t.Parallel()
+
+	testReaderEqual[[]byte](t)
+	testReaderEqual[string](t)
+}
+
+func testReaderEqual[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		off  int64
+		s    string
+		want bool
+	}{
+		{0, "hello, world", true},
+		{0, "hello", false},
+		{7, "world", true},
+		{7, "World", false},
+		{7, "worlds", false},
+		{12, "", true},
+		{100, "", true},
+		{100, "x", false},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("hello, world"))
+		for _, tt := range tests {
+			_, _ = r.Seek(tt.off, io.SeekStart)
+			if got := r.EqualString(tt.s); tt.want != got {
+				t.Errorf("at %d: EqualString(%q) = %t; want %t", tt.off, tt.s, got, tt.want)
+			}
+			if got := r.EqualBytes([]byte(tt.s)); tt.want != got {
+				t.Errorf("at %d: EqualBytes(%q) = %t; want %t", tt.off, tt.s, got, tt.want)
+			}
+			if off, _ := r.Seek(0, io.SeekCurrent); off != tt.off {
+				t.Errorf("after Equal: offset = %d; want %d", off, tt.off)
+			}
+		}
+		if !r.EqualBytes(nil) {
+			t.Error("EqualBytes(nil) at EOF = false; want true")
+		}
+	})
+}
+
+func TestReaderEqualAllocs(t *testing.T) {
+	r := New([]byte("hello, world"))
+	p := []byte("hello, world")
+	if n := testing.AllocsPerRun(100, func() {
+		_ = r.EqualBytes(p)
+		_ = r.EqualString("hello, world")
+		_ = r.MatchAt(7, p[7:])
+		_ = r.HasPrefix(p[:5])
+		_ = r.HasPrefixString("hello")
+		_ = r.HasSuffixString("world")
+	}); n != 0 {
+		t.Errorf("EqualBytes, EqualString, MatchAt and HasPrefix allocs = %v; want 0", n)
+	}
+}
+
+func TestReaderMatchAt(t *testing.T) {
+	t.Parallel()
+
+	testReaderMatchAt[[]byte](t)
+	testReaderMatchAt[string](t)
+}
+
+func testReaderMatchAt[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		off     int64
+		pattern string
+		want    bool
+	}{
+		{0, "hello", true},
+		{7, "world", true},
+		{7, "World", false},
+		{8, "world", false},
+		{12, "", true},
+		{11, "dd", false},
+		{13, "", false},
+		{-1, "", false},
+		{1 << 62, "x", false},
+		{0, "hello, world!", false},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("hello, world"))
+		_, _ = r.Seek(3, io.SeekStart)
+		_, _, _ = r.ReadRune()
+		for _, tt := range tests {
+			if got := r.MatchAt(tt.off, []byte(tt.pattern)); tt.want != got {
+				t.Errorf("MatchAt(%d, %q) = %t; want %t", tt.off, tt.pattern, got, tt.want)
+			}
+		}
+		if r.Len() != 8 {
+			t.Errorf("MatchAt moved the Reader: Len = %d; want 8", r.Len())
+		}
+		if err := r.UnreadRune(); err != nil {
+			t.Errorf("UnreadRune after MatchAt: %v", err)
+		}
+	})
+}
+
+func TestReaderHasPrefixFold(t *testing.T) {
+	t.Parallel()
+
+	testReaderHasPrefixFold[[]byte](t)
+	testReaderHasPrefixFold[string](t)
+}
+
+func testReaderHasPrefixFold[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s, prefix string
+		want      bool
+		n         int // bytes consumed by ExpectFold
+	}{
+		{"Content-Length: 42", "content-length:", true, 15},
+		{"CONTENT-TYPE", "content-length", false, 0},
+		{"abc", "", true, 0},
+		{"", "", true, 0},
+		{"ab", "abc", false, 0},
+		{"\u212aelvin", "kelvin", true, 8}, // Kelvin sign, 3 bytes, folds to k
+		{"kelvin", "\u212aELVIN", true, 6},
+		{"\u017fun", "SUN", true, 4}, // long s folds to s
+		{"Straße", "STRASSE", false, 0},
+		{"ΣΊΣΥΦΟΣ", "σίσυφος", true, len("ΣΊΣΥΦΟΣ")},
+		{"\xffx", "\xffX", true, 2},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			if got := r.HasPrefixFold(tt.prefix); got != tt.want {
+				t.Errorf("HasPrefixFold(%q, %q) = %t; want %t", tt.s, tt.prefix, got, tt.want)
+			}
+			if r.Len() != len(tt.s) {
+				t.Errorf("HasPrefixFold(%q, %q) modified the Reader: Len = %d", tt.s, tt.prefix, r.Len())
+			}
+			if got := r.ExpectFold(tt.prefix); got != tt.want {
+				t.Errorf("ExpectFold(%q, %q) = %t; want %t", tt.s, tt.prefix, got, tt.want)
+			}
+			if r.Len() != len(tt.s)-tt.n {
+				t.Errorf("ExpectFold(%q, %q): Len = %d; want %d", tt.s, tt.prefix, r.Len(), len(tt.s)-tt.n)
+			}
+		}
+	})
+}
+
+func TestReaderHasPrefix(t *testing.T) {
+	t.Parallel()
+
+	testReaderHasPrefix[[]byte](t)
+	testReaderHasPrefix[string](t)
+}
+
+func testReaderHasPrefix[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("GET /index.html\r\n"))
+		_, _ = r.Seek(4, io.SeekStart)
+		tests := []struct {
+			p              string
+			prefix, suffix bool
+		}{
+			{"/index", true, false},
+			{"GET", false, false},
+			{".html\r\n", false, true},
+			{"/index.html\r\n", true, true},
+			{"", true, true},
+			{"x/index.html\r\n", false, false},
+		}
+		for _, tt := range tests {
+			if got := r.HasPrefix(S(tt.p)); got != tt.prefix {
+				t.Errorf("HasPrefix(%q) = %t; want %t", tt.p, got, tt.prefix)
+			}
+			if got := r.HasPrefixString(tt.p); got != tt.prefix {
+				t.Errorf("HasPrefixString(%q) = %t; want %t", tt.p, got, tt.prefix)
+			}
+			if got := r.HasSuffix(S(tt.p)); got != tt.suffix {
+				t.Errorf("HasSuffix(%q) = %t; want %t", tt.p, got, tt.suffix)
+			}
+			if got := r.HasSuffixString(tt.p); got != tt.suffix {
+				t.Errorf("HasSuffixString(%q) = %t; want %t", tt.p, got, tt.suffix)
+			}
+		}
+		if r.Len() != 13 {
+			t.Errorf("HasPrefix modified the Reader: Len = %d; want 13", r.Len())
+		}
+
+		// HasSuffix sees the end left by ReadLastRune.
+		_, _, _ = r.ReadLastRune()
+		if !r.HasSuffix(S(".html\r")) || r.HasSuffixString("\r\n") {
+			t.Error("HasSuffix after ReadLastRune does not respect the new end")
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package reader
+
+import "unsafe"
+
+// asString returns the contents of s as a string without copying.
+// The string header is a prefix of the slice header, so the conversion
+// is valid for both members of the type set.
+// The caller must not modify the bytes while the result is in use.
+func asString[S ~[]byte | ~string](s S) string {
+	return *(*string)(unsafe.Pointer(&s))
+}
+
+// asBytes returns the contents of s as a byte slice without copying.
+// The result must not be modified.
+func asBytes[S ~[]byte | ~string](s S) []byte {
+	p := asString(s)
+	return unsafe.Slice(unsafe.StringData(p), len(p))
+}
+
+// offsetIn returns the offset of sub within s if sub is a view into the
+// memory of s.
+func offsetIn(s, sub []byte) (int, bool) {
+	if len(sub) == 0 || len(sub) > len(s) {
+		return 0, false
+	}
+	d := uintptr(unsafe.Pointer(unsafe.SliceData(sub))) - uintptr(unsafe.Pointer(unsafe.SliceData(s)))
+	if d > uintptr(len(s)-len(sub)) {
+		return 0, false
+	}
+	return int(d), true
+}
@@ -0,0 +1,96 @@
+package reader
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// ReadCSVField reads a single field of RFC 4180 CSV data starting at the
+// current position and advances the Reader past the field and the comma
+// or line ending ("\n" or "\r\n") that follows it.
+// A field enclosed in double quotes may contain commas, line endings and
+// doubled quotes, which are unescaped to a single quote; the contents of
+// a quoted field are otherwise returned unchanged.
+// eol reports whether the field was the last of its line, that is,
+// whether it was followed by a line ending or by the end of the data.
+// A comma at the end of the data is followed by one more, empty, field,
+// which the next call returns unless the Reader has been moved by Seek,
+// Replay or Reset in between.
+// ReadCSVField returns io.EOF only when there is no field left to read.
+// A quoted field missing its closing quote yields io.ErrUnexpectedEOF,
+// and a malformed field an error wrapping csv.ErrBareQuote or
+// csv.ErrQuote; on error the Reader is not advanced.
+func (r *Reader[S]) ReadCSVField() (field string, eol bool, err error) {
+	r.lastRead = opInvalid
+	finalComma := r.csvComma
+	r.csvComma = false
+	s := asString(r.unread())
+	if len(s) == 0 {
+		if finalComma && r.off == r.end() {
+			// The empty field after a final comma.
+			return "", true, nil
+		}
+		return "", false, io.EOF
+	}
+
+	var end int // end of the field, including any quotes
+	if s[0] != '"' {
+		end = strings.IndexAny(s, ",\n")
+		if end < 0 {
+			end = len(s)
+		}
+		field = s[:end]
+		if end < len(s) && s[end] == '\n' && strings.HasSuffix(field, "\r") {
+			field = field[:len(field)-1]
+		}
+		if i := strings.IndexByte(field, '"'); i >= 0 {
+			return "", false, &ReaderError{Method: "ReadCSVField", Offset: r.off + int64(i), Cause: csv.ErrBareQuote}
+		}
+		field = string(r.unread()[:len(field)])
+	} else {
+		var b strings.Builder
+		escaped := false
+		for i := 1; ; {
+			j := strings.IndexByte(s[i:], '"')
+			if j < 0 {
+				return "", false, io.ErrUnexpectedEOF
+			}
+			j += i
+			if j+1 < len(s) && s[j+1] == '"' {
+				// A doubled quote stands for a single one.
+				b.WriteString(s[i : j+1])
+				escaped = true
+				i = j + 2
+				continue
+			}
+			if escaped {
+				b.WriteString(s[i:j])
+				field = b.String()
+			} else {
+				field = string(r.unread()[1:j])
+			}
+			end = j + 1
+			break
+		}
+		if strings.HasPrefix(s[end:], "\r\n") {
+			end++
+		}
+		if end < len(s) && s[end] != ',' && s[end] != '\n' {
+			return "", false, &ReaderError{Method: "ReadCSVField", Offset: r.off + int64(end), Cause: csv.ErrQuote}
+		}
+	}
+
+	switch {
+	case end == len(s):
+		eol = true
+	case s[end] == ',':
+		end++
+		r.csvComma = end == len(s)
+	default: // '\n'
+		eol = true
+		end++
+	}
+	r.off += int64(end)
+	return field, eol, nil
+}
@@ -0,0 +1,187 @@
+package reader_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+// readCSVRecords reads all records from r with ReadCSVField.
+func readCSVRecords[S ~[]byte | ~string](r *Reader[S]) ([][]string, error) {
+	var records [][]string
+	var record []string
+	for {
+		field, eol, err := r.ReadCSVField()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		record = append(record, field)
+		if eol {
+			records = append(records, record)
+			record = nil
+		}
+	}
+}
+
+func TestReaderReadCSVField(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadCSVField[[]byte](t)
+	testReaderReadCSVField[string](t)
+}
+
+func testReaderReadCSVField[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s    string
+		want [][]string
+	}{
+		{"", nil},
+		{"a", [][]string{{"a"}}},
+		{"a,b,c\n", [][]string{{"a", "b", "c"}}},
+		{"a,b\r\nc,d", [][]string{{"a", "b"}, {"c", "d"}}},
+		{"a,b,", [][]string{{"a", "b", ""}}},
+		{",", [][]string{{"", ""}}},
+		{"\n\n", [][]string{{""}, {""}}},
+		{`"a,b","c""d"`, [][]string{{"a,b", `c"d`}}},
+		{"\"x\ny\"\r\n\"\",z\n", [][]string{{"x\ny"}, {"", "z"}}},
+		{`"""",""""""`, [][]string{{`"`, `""`}}},
+		{"a\rb,c", [][]string{{"a\rb", "c"}}},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := readCSVRecords(r)
+			if !reflect.DeepEqual(tt.want, got) || err != nil {
+				t.Errorf("ReadCSVField(%q) records = %q, %v; want %q, nil", tt.s, got, err, tt.want)
+			}
+		}
+	})
+}
+
+func TestReaderReadCSVFieldTrailingComma(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadCSVFieldTrailingComma[[]byte](t)
+	testReaderReadCSVFieldTrailingComma[string](t)
+}
+
+func testReaderReadCSVFieldTrailingComma[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("a,"))
+		if field, eol, err := r.ReadCSVField(); field != "a" || eol || err != nil {
+			t.Fatalf("ReadCSVField = %q, %v, %v; want \"a\", false, nil", field, eol, err)
+		}
+		// Other calls between fields must not lose the trailing empty field.
+		_, _ = r.Seek(0, io.SeekCurrent)
+		_, _ = r.ReadByte()
+		if field, eol, err := r.ReadCSVField(); field != "" || !eol || err != nil {
+			t.Errorf("ReadCSVField after final comma = %q, %v, %v; want \"\", true, nil", field, eol, err)
+		}
+		if _, _, err := r.ReadCSVField(); err != io.EOF {
+			t.Errorf("ReadCSVField after trailing field error = %v; want EOF", err)
+		}
+		if r.Len() != 0 || r.Size() != 2 {
+			t.Errorf("Len, Size after trailing field = %d, %d; want 0, 2", r.Len(), r.Size())
+		}
+		if off, _ := r.Seek(0, io.SeekCurrent); off != 2 {
+			t.Errorf("offset after trailing field = %d; want 2", off)
+		}
+
+		// Reaching the end by other means yields no phantom field.
+		_, _ = r.Seek(0, io.SeekStart)
+		_, _ = io.ReadAll(r)
+		if field, eol, err := r.ReadCSVField(); err != io.EOF {
+			t.Errorf("ReadCSVField after ReadAll = %q, %v, %v; want EOF", field, eol, err)
+		}
+		_, _ = r.Seek(0, io.SeekStart)
+		_, _, _ = r.ReadCSVField()
+		_, _ = r.Seek(0, io.SeekStart)
+		_, _ = r.Seek(0, io.SeekEnd)
+		if field, eol, err := r.ReadCSVField(); err != io.EOF {
+			t.Errorf("ReadCSVField after Seek to the end = %q, %v, %v; want EOF", field, eol, err)
+		}
+	})
+}
+
+func TestReaderReadCSVFieldMatchesEncodingCSV(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadCSVFieldMatchesEncodingCSV[[]byte](t)
+	testReaderReadCSVFieldMatchesEncodingCSV[string](t)
+}
+
+func testReaderReadCSVFieldMatchesEncodingCSV[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const s = "name,quote\n" +
+		"gopher,\"Don't communicate by sharing memory, share memory by communicating.\"\r\n" +
+		"\"Rob \"\"Commander\"\" Pike\",\"Clear is better\nthan clever.\"\n"
+
+	cr := csv.NewReader(strings.NewReader(s))
+	cr.FieldsPerRecord = -1
+	want, err := cr.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(s))
+		got, err := readCSVRecords(r)
+		if !reflect.DeepEqual(want, got) || err != nil {
+			t.Errorf("records = %q, %v; want %q, nil", got, err, want)
+		}
+	})
+}
+
+func TestReaderReadCSVFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadCSVFieldErrors[[]byte](t)
+	testReaderReadCSVFieldErrors[string](t)
+}
+
+func testReaderReadCSVFieldErrors[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		wanterr error
+		wantmsg string
+	}{
+		{`x,a"b`, csv.ErrBareQuote, `reader.Reader.ReadCSVField: bare " in non-quoted-field at offset 3`},
+		{`x,"a"b`, csv.ErrQuote, `reader.Reader.ReadCSVField: extraneous or missing " in quoted-field at offset 5`},
+		{`x,"ab`, io.ErrUnexpectedEOF, "unexpected EOF"},
+		{`x,"a""`, io.ErrUnexpectedEOF, "unexpected EOF"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			if _, _, err := r.ReadCSVField(); err != nil {
+				t.Fatalf("ReadCSVField(%q) first field error = %v", tt.s, err)
+			}
+			_, _, err := r.ReadCSVField()
+			if !errors.Is(err, tt.wanterr) || err.Error() != tt.wantmsg {
+				t.Errorf("ReadCSVField(%q) error = %v; want %s", tt.s, err, tt.wantmsg)
+			}
+			// The Reader is left at the start of the malformed field.
+			if r.Len() != len(tt.s)-2 {
+				t.Errorf("ReadCSVField(%q): Len = %d; want %d", tt.s, r.Len(), len(tt.s)-2)
+			}
+		}
+	})
+}
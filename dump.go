@@ -0,0 +1,33 @@
+package reader
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// HexDump returns a hex dump of the unread portion of the slice or string
+// in the format of hex.Dump, except that each line starts with the
+// absolute offset of its first byte in the underlying data rather than
+// the offset from the start of the dump.
+// HexDump does not modify the Reader.
+func (r *Reader[S]) HexDump() string {
+	dump := hex.Dump(asBytes(r.unread()))
+	if dump == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(dump))
+	off := r.off
+	for _, line := range strings.SplitAfter(dump, "\n") {
+		if line == "" {
+			continue
+		}
+		// Replace the relative offset, the first 8 hex digits of each line.
+		fmt.Fprintf(&b, "%08x", off)
+		b.WriteString(line[8:])
+		off += 16
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package reader_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderHexDump(t *testing.T) {
+	t.Parallel()
+
+	testReaderHexDump[[]byte](t)
+	testReaderHexDump[string](t)
+}
+
+func testReaderHexDump[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789abcdefghijklmnopqrstuvwxyz"))
+		if got := r.HexDump(); got == "" {
+			t.Error("HexDump: got empty dump")
+		}
+
+		if _, err := r.Seek(10, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		const want = "0000000a  61 62 63 64 65 66 67 68  69 6a 6b 6c 6d 6e 6f 70  |abcdefghijklmnop|\n" +
+			"0000001a  71 72 73 74 75 76 77 78  79 7a                    |qrstuvwxyz|\n"
+		if got := r.HexDump(); want != got {
+			t.Errorf("HexDump:\ngot:\n%s\nwant:\n%s", got, want)
+		}
+		if r.Len() != 26 {
+			t.Errorf("Len = %d; want 26", r.Len())
+		}
+
+		if _, err := r.Seek(0, io.SeekEnd); err != nil {
+			t.Fatal(err)
+		}
+		if got := r.HexDump(); got != "" {
+			t.Errorf("at EOF: HexDump = %q; want empty", got)
+		}
+	})
+}
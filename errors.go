@@ -0,0 +1,69 @@
+package reader
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by the methods of Reader, usually wrapped in a
+// *ReaderError, so that they are to be tested for with errors.Is.
+// Truncated data is reported with io.EOF or io.ErrUnexpectedEOF instead.
+var (
+	ErrNegativeOffset   = errors.New("negative offset")
+	ErrNegativePosition = errors.New("negative position")
+	ErrInvalidWhence    = errors.New("invalid whence")
+	ErrAtBeginning      = errors.New("at beginning of slice or string")
+	ErrUnreadRune       = errors.New("previous operation was not ReadRune")
+
+	ErrNegativeCount    = errors.New("negative count")
+	ErrInvalidAlignment = errors.New("non-positive alignment")
+	ErrInvalidWidth     = errors.New("invalid width")
+	ErrInvalidType      = errors.New("invalid type")
+	ErrLineOutOfRange   = errors.New("line number out of range")
+	ErrOutOfRange       = errors.New("range out of bounds")
+	ErrUnanchored       = errors.New("regexp not anchored at the start")
+
+	ErrOverflow    = errors.New("integer overflow")
+	ErrTooLong     = errors.New("exceeds maximum length")
+	ErrInvalidUTF8 = errors.New("invalid UTF-8")
+	ErrSyntax      = errors.New("syntax error")
+)
+
+// A ReaderError records an error from a method of Reader, or of another
+// reader type of this package, and the offset in the data at which it
+// occurred.
+type ReaderError struct {
+	Method string // the method, such as "Seek", or "ReverseReader.Seek" for other types
+	Offset int64  // the offset of the problem, the negative offset passed to ReadAt or Seek, or that of the Reader for other invalid arguments
+	Cause  error  // the underlying error
+}
+
+func (e *ReaderError) Error() string {
+	method := e.Method
+	if !strings.Contains(method, ".") {
+		method = "Reader." + method
+	}
+	s := "reader." + method + ": " + e.Cause.Error()
+	if e.Offset >= 0 {
+		// A negative Offset is the bad argument itself, which is not
+		// a position in the data.
+		s += " at offset " + strconv.FormatInt(e.Offset, 10)
+	}
+	return s
+}
+
+func (e *ReaderError) Unwrap() error { return e.Cause }
+
+// An InvalidUTF8Error is returned in strict UTF-8 mode when the data
+// holds an invalid UTF-8 sequence where a rune was expected.
+// It wraps ErrInvalidUTF8.
+type InvalidUTF8Error struct {
+	Offset int64 // offset of the invalid sequence
+}
+
+func (e *InvalidUTF8Error) Error() string {
+	return "reader: invalid UTF-8 at offset " + strconv.FormatInt(e.Offset, 10)
+}
+
+func (e *InvalidUTF8Error) Unwrap() error { return ErrInvalidUTF8 }
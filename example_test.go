@@ -2,6 +2,7 @@ package reader_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/weiwenchen2022/reader"
 )
@@ -13,3 +14,14 @@ func ExampleReader_Len() {
 	// 3 3
 	// 16 16
 }
+
+func ExampleReader_FieldsFuncSeq() {
+	r := reader.New("name,age;alice,30;bob,25")
+	isSep := func(c rune) bool { return c == ',' || c == ';' }
+	for f := range r.FieldsFuncSeq(isSep) {
+		fmt.Print(strings.ToUpper(f), " ")
+	}
+	fmt.Println()
+	// Output:
+	// NAME AGE ALICE 30 BOB 25
+}
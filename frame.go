@@ -0,0 +1,64 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+	"iter"
+)
+
+// SetMaxFrameSize sets the largest frame size ReadFrame accepts.
+// Frames declaring a larger size are rejected before any of their data
+// is examined. A size of 0, the default, means no limit beyond the data
+// at hand. SetMaxFrameSize panics if n is negative.
+func (r *Reader[S]) SetMaxFrameSize(n int) {
+	if n < 0 {
+		panic("reader.Reader.SetMaxFrameSize: negative size")
+	}
+	r.options().maxFrameSize = n
+}
+
+// ReadFrame reads a frame made of a uvarint length followed by that many
+// bytes, as used by protobuf-style delimited streams, and returns the
+// bytes as a view into the underlying data.
+// If no data remains, ReadFrame returns io.EOF. If the data ends before
+// the frame does, it returns io.ErrUnexpectedEOF, and if the length
+// exceeds the maximum set by SetMaxFrameSize, an error; in either case,
+// and for a malformed length, the Reader is not advanced.
+func (r *Reader[S]) ReadFrame() (S, error) {
+	start := r.off
+	n, err := r.readUvarint("ReadFrame")
+	if err != nil {
+		return r.s[:0], err
+	}
+	if r.opts != nil && r.opts.maxFrameSize > 0 && n > uint64(r.opts.maxFrameSize) {
+		r.off = start
+		return r.s[:0], &ReaderError{Method: "ReadFrame", Offset: start, Cause: fmt.Errorf("frame of %d bytes %w of %d", n, ErrTooLong, r.opts.maxFrameSize)}
+	}
+
+	s := r.unread()
+	if n > uint64(len(s)) {
+		r.off = start
+		return s[:0], io.ErrUnexpectedEOF
+	}
+	r.off += int64(n)
+	return s[:n], nil
+}
+
+// Frames returns an iterator over the frames of the unread data, as read
+// by ReadFrame. The iteration ends at the end of the data or after
+// yielding the first error, with an empty frame; the Reader is then left
+// positioned at the start of the bad frame.
+// The Reader is advanced past each frame before it is yielded.
+func (r *Reader[S]) Frames() iter.Seq2[S, error] {
+	return func(yield func(S, error) bool) {
+		for {
+			frame, err := r.ReadFrame()
+			if err == io.EOF {
+				return
+			}
+			if !yield(frame, err) || err != nil {
+				return
+			}
+		}
+	}
+}
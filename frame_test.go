@@ -0,0 +1,138 @@
+package reader_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func appendFrames(buf []byte, frames ...string) []byte {
+	for _, f := range frames {
+		buf = binary.AppendUvarint(buf, uint64(len(f)))
+		buf = append(buf, f...)
+	}
+	return buf
+}
+
+func TestReaderReadFrame(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadFrame[[]byte](t)
+	testReaderReadFrame[string](t)
+}
+
+func testReaderReadFrame[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	frames := []string{"hello", "", strings.Repeat("x", 300), "world"}
+	data := appendFrames(nil, frames...)
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(data))
+		for _, want := range frames {
+			got, err := r.ReadFrame()
+			if want != string(got) || err != nil {
+				t.Errorf("ReadFrame = %q, %v; want %q, nil", got, err, want)
+			}
+		}
+		if _, err := r.ReadFrame(); err != io.EOF {
+			t.Errorf("at EOF: ReadFrame error = %v; want EOF", err)
+		}
+
+		// A truncated final frame is not consumed.
+		truncated := appendFrames(nil, "one", "two")
+		truncated = truncated[:len(truncated)-1]
+		r = New(S(truncated))
+		if got, err := r.ReadFrame(); string(got) != "one" || err != nil {
+			t.Errorf("ReadFrame = %q, %v; want %q, nil", got, err, "one")
+		}
+		if got, err := r.ReadFrame(); len(got) != 0 || err != io.ErrUnexpectedEOF {
+			t.Errorf("truncated: ReadFrame = %q, %v; want \"\", unexpected EOF", got, err)
+		}
+		if r.Len() != len("\x03tw") {
+			t.Errorf("truncated: Len = %d; want %d", r.Len(), len("\x03tw"))
+		}
+
+		// A hostile length is rejected before the data is examined.
+		r = New(S(binary.AppendUvarint(nil, 1<<62)))
+		if _, err := r.ReadFrame(); err != io.ErrUnexpectedEOF {
+			t.Errorf("huge length: ReadFrame error = %v; want unexpected EOF", err)
+		}
+		if r.Len() == 0 {
+			t.Errorf("huge length: Reader advanced")
+		}
+	})
+}
+
+func TestReaderMaxFrameSize(t *testing.T) {
+	t.Parallel()
+
+	testReaderMaxFrameSize[[]byte](t)
+	testReaderMaxFrameSize[string](t)
+}
+
+func testReaderMaxFrameSize[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(appendFrames([]byte("x"), "tiny", "too large")))
+		_, _ = r.ReadByte()
+		r.SetMaxFrameSize(4)
+		if _, err := r.ReadFrame(); err != nil {
+			t.Fatalf("ReadFrame error = %v", err)
+		}
+		want := "reader.Reader.ReadFrame: frame of 9 bytes exceeds maximum length of 4 at offset 6"
+		if _, err := r.ReadFrame(); err == nil || err.Error() != want {
+			t.Errorf("ReadFrame error = %v; want %s", err, want)
+		}
+		if r.Len() != len("\ttoo large") {
+			t.Errorf("Len = %d; want %d", r.Len(), len("\ttoo large"))
+		}
+
+		// The limit survives Reset.
+		r.Reset(S(appendFrames(nil, "too large")))
+		if _, err := r.ReadFrame(); err == nil {
+			t.Errorf("after Reset: ReadFrame error = nil; want frame size error")
+		}
+		r.SetMaxFrameSize(0)
+		if _, err := r.ReadFrame(); err != nil {
+			t.Errorf("no limit: ReadFrame error = %v", err)
+		}
+	})
+}
+
+func TestReaderFrames(t *testing.T) {
+	t.Parallel()
+
+	data := appendFrames(nil, "a", "bc", "def")
+	r := New(append(data, 0x05, 'x'))
+	var got []string
+	var errs []error
+	for frame, err := range r.Frames() {
+		got = append(got, string(frame))
+		errs = append(errs, err)
+	}
+	if want := []string{"a", "bc", "def", ""}; fmt.Sprintf("%q", want) != fmt.Sprintf("%q", got) {
+		t.Errorf("Frames = %q; want %q", got, want)
+	}
+	if errs[len(errs)-1] != io.ErrUnexpectedEOF {
+		t.Errorf("Frames final error = %v; want unexpected EOF", errs[len(errs)-1])
+	}
+	if r.Len() != 2 {
+		t.Errorf("after Frames: Len = %d; want 2", r.Len())
+	}
+
+	sr := New(string(data))
+	for frame := range sr.Frames() {
+		if frame == "bc" {
+			break
+		}
+	}
+	if sr.Len() != len("\x03def") {
+		t.Errorf("after break: Len = %d; want %d", sr.Len(), len("\x03def"))
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/weiwenchen2022/reader
 
-go 1.20
+go 1.23
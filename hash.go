@@ -0,0 +1,38 @@
+package reader
+
+import (
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+)
+
+// Hash writes the unread portion of the slice or string to h and
+// advances the Reader to the end. It returns the number of bytes written.
+// Hash does not call h.Sum; reading the digest is left to the caller.
+// The data is written in place, without copying it even for a string.
+func (r *Reader[S]) Hash(h hash.Hash) (int64, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if len(s) == 0 {
+		return 0, nil
+	}
+	n, err := h.Write(asBytes(s))
+	r.off += int64(n)
+	r.stats().bytes(int64(n))
+	return int64(n), err
+}
+
+// CRC32 returns the CRC-32 checksum of the unread portion of the slice
+// or string using the polynomial represented by tab.
+// A nil tab selects the IEEE polynomial.
+// CRC32 does not modify the Reader.
+func (r *Reader[S]) CRC32(tab *crc32.Table) uint32 {
+	if tab == nil {
+		tab = crc32.IEEETable
+	}
+	return crc32.Checksum(asBytes(r.unread()), tab)
+}
+
+// Adler32 returns the Adler-32 checksum of the unread portion of the
+// slice or string. Adler32 does not modify the Reader.
+func (r *Reader[S]) Adler32() uint32 { return adler32.Checksum(asBytes(r.unread())) }
@@ -0,0 +1,95 @@
+package reader_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash/adler32"
+	"hash/crc32"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderHash(t *testing.T) {
+	t.Parallel()
+
+	testReaderHash[[]byte](t)
+	testReaderHash[string](t)
+}
+
+func testReaderHash[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789"))
+		if _, err := r.Seek(3, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+
+		h := sha256.New()
+		n, err := r.Hash(h)
+		if n != 7 || err != nil {
+			t.Errorf("Hash = %d, %v; want 7, nil", n, err)
+		}
+		if want := sha256.Sum256([]byte("3456789")); string(want[:]) != string(h.Sum(nil)) {
+			t.Errorf("Hash: got digest %x, want %x", h.Sum(nil), want)
+		}
+		if r.Len() != 0 {
+			t.Errorf("Len = %d; want 0", r.Len())
+		}
+	})
+}
+
+func TestReaderChecksums(t *testing.T) {
+	t.Parallel()
+
+	testReaderChecksums[[]byte](t)
+	testReaderChecksums[string](t)
+}
+
+func testReaderChecksums[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	castagnoli := crc32.MakeTable(crc32.Castagnoli)
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(testString))
+		if _, err := r.Seek(100, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		rest := []byte(testString[100:])
+
+		if got, want := r.CRC32(nil), crc32.ChecksumIEEE(rest); want != got {
+			t.Errorf("CRC32(nil) = %#x; want %#x", got, want)
+		}
+		if got, want := r.CRC32(castagnoli), crc32.Checksum(rest, castagnoli); want != got {
+			t.Errorf("CRC32(castagnoli) = %#x; want %#x", got, want)
+		}
+		if got, want := r.Adler32(), adler32.Checksum(rest); want != got {
+			t.Errorf("Adler32 = %#x; want %#x", got, want)
+		}
+		if r.Len() != len(rest) {
+			t.Errorf("Len = %d; want %d", r.Len(), len(rest))
+		}
+	})
+}
+
+func TestReaderChecksumsAllocs(t *testing.T) {
+	r := New(testString)
+	if n := testing.AllocsPerRun(10, func() { _ = r.CRC32(nil) + r.Adler32() }); n != 0 {
+		t.Errorf("checksum allocs = %v; want 0", n)
+	}
+}
+
+func TestReaderHashAllocs(t *testing.T) {
+	h := crc32.NewIEEE()
+	r := New(testString)
+	n := testing.AllocsPerRun(10, func() {
+		_, _ = r.Seek(0, io.SeekStart)
+		_, _ = r.Hash(h)
+	})
+	if n != 0 {
+		t.Errorf("Hash allocs = %v; want 0", n)
+	}
+}
@@ -0,0 +1,78 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+)
+
+// ReadHex decodes n bytes from the next 2*n hexadecimal digits, in
+// either case, and advances the Reader past them. If skipSpace is true,
+// ASCII white space between digits, such as line breaks in a hex dump,
+// is skipped; white space before the first digit or after the last is
+// not.
+// On error ReadHex does not advance. A byte that is not a hex digit
+// yields an error holding its offset; if the data ends first, ReadHex
+// returns io.EOF if no data remained and io.ErrUnexpectedEOF otherwise.
+// It returns an error if n is negative.
+func (r *Reader[S]) ReadHex(n int, skipSpace bool) ([]byte, error) {
+	if n < 0 {
+		return nil, &ReaderError{Method: "ReadHex", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	return r.appendHex("ReadHex", make([]byte, 0, n), n, skipSpace)
+}
+
+// AppendHex is like ReadHex but appends the decoded bytes to dst and
+// returns the extended slice, so that a buffer can be reused.
+// On error dst is returned unchanged.
+func (r *Reader[S]) AppendHex(dst []byte, n int, skipSpace bool) ([]byte, error) {
+	if n < 0 {
+		return dst, &ReaderError{Method: "AppendHex", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	return r.appendHex("AppendHex", dst, n, skipSpace)
+}
+
+func (r *Reader[S]) appendHex(method string, dst []byte, n int, skipSpace bool) ([]byte, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 && n > 0 {
+		return dst, io.EOF
+	}
+
+	orig := len(dst)
+	i := 0
+	for k := 0; k < 2*n; k++ {
+		if skipSpace && k > 0 {
+			for i < len(s) && asciiSpace[s[i]] {
+				i++
+			}
+		}
+		if i >= len(s) {
+			return dst[:orig], io.ErrUnexpectedEOF
+		}
+		v, ok := fromHexChar(s[i])
+		if !ok {
+			return dst[:orig], &ReaderError{Method: method, Offset: r.off + int64(i), Cause: fmt.Errorf("%w: invalid hex digit %q", ErrSyntax, s[i])}
+		}
+		if k%2 == 0 {
+			dst = append(dst, v<<4)
+		} else {
+			dst[len(dst)-1] |= v
+		}
+		i++
+	}
+	r.off += int64(i)
+	return dst, nil
+}
+
+// fromHexChar converts a hex digit into its value.
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
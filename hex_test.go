@@ -0,0 +1,91 @@
+package reader_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderReadHex(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadHex[[]byte](t)
+	testReaderReadHex[string](t)
+}
+
+func testReaderReadHex[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s         string
+		n         int
+		skipSpace bool
+		want      string
+		wantLen   int
+		wanterr   any
+	}{
+		{"48656c6C6F!", 5, false, "Hello", 1, nil},
+		{"DEADbeef", 4, false, "\xde\xad\xbe\xef", 0, nil},
+		{"00ff", 0, false, "", 4, nil},
+		{"de ad\nbe\r\nef ", 4, true, "\xde\xad\xbe\xef", 1, nil},
+		{"d e", 1, true, "\xde", 0, nil},
+		{"dead\nbeef", 4, false, "", 9, "reader.Reader.ReadHex: syntax error: invalid hex digit '\\n' at offset 5"},
+		{" dead", 2, true, "", 5, "reader.Reader.ReadHex: syntax error: invalid hex digit ' ' at offset 1"},
+		{"dexd", 2, false, "", 4, "reader.Reader.ReadHex: syntax error: invalid hex digit 'x' at offset 3"},
+		{"dea", 2, false, "", 3, io.ErrUnexpectedEOF},
+		{"de\n", 2, true, "", 3, io.ErrUnexpectedEOF},
+		{"", 1, false, "", 0, io.EOF},
+		{"de", -1, false, "", 2, "reader.Reader.ReadHex: negative count at offset 1"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S("x" + tt.s))
+			_, _ = r.ReadByte()
+			got, err := r.ReadHex(tt.n, tt.skipSpace)
+			if tt.wanterr != nil {
+				if err == nil || fmt.Sprint(tt.wanterr) != err.Error() {
+					t.Errorf("ReadHex(%q, %d, %t) error = %v; want %v", tt.s, tt.n, tt.skipSpace, err, tt.wanterr)
+				}
+			} else if tt.want != string(got) || err != nil {
+				t.Errorf("ReadHex(%q, %d, %t) = %q, %v; want %q, nil", tt.s, tt.n, tt.skipSpace, got, err, tt.want)
+			}
+			if r.Len() != tt.wantLen {
+				t.Errorf("ReadHex(%q, %d, %t): Len = %d; want %d", tt.s, tt.n, tt.skipSpace, r.Len(), tt.wantLen)
+			}
+		}
+	})
+}
+
+func TestReaderAppendHex(t *testing.T) {
+	testReaderAppendHex[[]byte](t)
+	testReaderAppendHex[string](t)
+
+	r := New("0123456789abcdef")
+	buf := make([]byte, 0, 8)
+	if allocs := testing.AllocsPerRun(100, func() {
+		r.Seek(0, io.SeekStart)
+		buf, _ = r.AppendHex(buf[:0], 8, false)
+	}); allocs != 0 {
+		t.Errorf("AppendHex allocs = %v; want 0", allocs)
+	}
+}
+
+func testReaderAppendHex[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("cafe0xbabe"))
+		buf := []byte("id=")
+		buf, err := r.AppendHex(buf, 2, false)
+		if string(buf) != "id=\xca\xfe" || err != nil {
+			t.Errorf("AppendHex = %q, %v; want %q, nil", buf, err, "id=\xca\xfe")
+		}
+		buf, err = r.AppendHex(buf, 2, false)
+		if string(buf) != "id=\xca\xfe" || err == nil {
+			t.Errorf("AppendHex of invalid digits = %q, %v; want %q, error", buf, err, "id=\xca\xfe")
+		}
+	})
+}
@@ -0,0 +1,359 @@
+package reader
+
+import (
+	"bufio"
+	"iter"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Bytes returns an iterator over the unread bytes.
+// The Reader is advanced past each byte before it is yielded,
+// so stopping the iteration early leaves the Reader positioned
+// just after the last yielded byte.
+func (r *Reader[S]) Bytes() iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		r.lastRead = opInvalid
+		for r.off < r.end() {
+			c := r.s[r.off]
+			r.off++
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// Runes returns an iterator over the unread runes, yielding the
+// absolute byte offset at which each rune starts along with the rune.
+// Invalid UTF-8 is yielded as utf8.RuneError, advancing by one byte,
+// as with ReadRune; in strict UTF-8 mode the iteration stops at it
+// instead, with the Reader positioned at the invalid sequence, and Err
+// reports it.
+// The Reader is advanced past each rune before it is yielded.
+func (r *Reader[S]) Runes() iter.Seq2[int64, rune] {
+	return func(yield func(int64, rune) bool) {
+		r.lastRead = opInvalid
+		r.setErr(nil)
+		for r.off < r.end() {
+			off := r.off
+			ch, size := rune(r.s[off]), 1
+			if ch >= utf8.RuneSelf {
+				ch, size = utf8.DecodeRune(asBytes(r.unread()))
+				if r.invalidRune(ch, size) {
+					r.setErr(&InvalidUTF8Error{Offset: off})
+					return
+				}
+			}
+			r.off += int64(size)
+			if !yield(off, ch) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the error that ended the last iteration by Runes,
+// FieldsFuncSeq or TokensSeq: an *InvalidUTF8Error in strict UTF-8 mode
+// for the first two, or the error returned by the split function for
+// TokensSeq. It returns nil if that iteration ran to the end of the data
+// or was stopped by the caller.
+func (r *Reader[S]) Err() error {
+	if r.opts == nil {
+		return nil
+	}
+	return r.opts.iterErr
+}
+
+// setErr records err as the error that ended an iteration, allocating
+// the options of the Reader only for a non-nil error.
+func (r *Reader[S]) setErr(err error) {
+	if err != nil || r.opts != nil {
+		r.options().iterErr = err
+	}
+}
+
+// Lines returns an iterator over the lines of the unread data.
+// Lines are terminated by "\n" or "\r\n"; the yielded lines do not
+// include the terminator. A final line without a terminator is yielded too.
+// The yielded lines are views into the underlying data, not copies.
+// The Reader is advanced past each line and its terminator before the
+// line is yielded, so stopping the iteration early leaves the Reader
+// positioned at the start of the next line.
+func (r *Reader[S]) Lines() iter.Seq[S] {
+	return func(yield func(S) bool) {
+		r.lastRead = opInvalid
+		for r.off < r.end() {
+			line := r.unread()
+			if i := strings.IndexByte(asString(line), '\n'); i >= 0 {
+				line = line[:i]
+				r.off++
+			}
+			r.off += int64(len(line))
+			if n := len(line); n > 0 && line[n-1] == '\r' {
+				line = line[:n-1]
+			}
+			if !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// LinesNumbered returns an iterator over the lines of the unread data,
+// as with Lines, yielding each line with its 1-based line number counted
+// from the current position.
+func (r *Reader[S]) LinesNumbered() iter.Seq2[int, S] { return r.LinesNumberedFrom(1) }
+
+// LinesNumberedFrom is like LinesNumbered but numbers the first line n.
+// After stopping an iteration early at line k, a caller can resume with
+// LinesNumberedFrom(k+1) to keep the numbering consistent.
+func (r *Reader[S]) LinesNumberedFrom(n int) iter.Seq2[int, S] {
+	return func(yield func(int, S) bool) {
+		for line := range r.Lines() {
+			if !yield(n, line) {
+				return
+			}
+			n++
+		}
+	}
+}
+
+// Chunks returns an iterator over successive chunks of the unread data,
+// each size bytes long except possibly the last, which may be shorter.
+// The yielded chunks are views into the underlying data, not copies.
+// The Reader is advanced past each chunk before it is yielded, so
+// stopping the iteration early leaves the Reader positioned at the
+// start of the next chunk.
+// Chunks panics if size is not positive.
+func (r *Reader[S]) Chunks(size int) iter.Seq[S] {
+	if size <= 0 {
+		panic("reader.Reader.Chunks: non-positive size")
+	}
+
+	return func(yield func(S) bool) {
+		r.lastRead = opInvalid
+		for r.off < r.end() {
+			chunk := r.unread()
+			if len(chunk) > size {
+				chunk = chunk[:size]
+			}
+			r.off += int64(len(chunk))
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Words returns an iterator over the runs of non-white-space bytes in
+// the unread data, using the same definition of white space as
+// SkipWhitespace. The Reader is advanced past each word and the white
+// space following it before the word is yielded, so stopping the
+// iteration early leaves the Reader positioned at the start of the
+// next word.
+func (r *Reader[S]) Words() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		r.SkipWhitespace()
+		for r.off < r.end() {
+			start := r.off
+			for r.off < r.end() && !asciiSpace[r.s[r.off]] {
+				r.off++
+			}
+			word := r.s[start:r.off]
+			r.SkipWhitespace()
+			if !yield(string(word)) {
+				return
+			}
+		}
+	}
+}
+
+// SplitSeq returns an iterator over the pieces of the unread data
+// separated by sep, as with strings.SplitSeq. The yielded pieces do not
+// include the separator and are views into the underlying data.
+// If sep is empty, SplitSeq splits after each UTF-8 sequence.
+// Adjacent separators yield empty pieces, and data ending with sep
+// yields a trailing empty piece.
+// The Reader is advanced past each piece and its separator before the
+// piece is yielded, so stopping the iteration early leaves the Reader
+// positioned at the start of the next piece.
+func (r *Reader[S]) SplitSeq(sep S) iter.Seq[S] {
+	return r.splitSeq(sep, 0)
+}
+
+// SplitAfterSeq returns an iterator over the pieces of the unread data
+// split after each instance of sep, as with strings.SplitAfterSeq.
+// The yielded pieces include the separator, except possibly the last,
+// and are views into the underlying data. Concatenating the pieces
+// reproduces the unread data.
+// The iteration consumes the Reader as SplitSeq does.
+func (r *Reader[S]) SplitAfterSeq(sep S) iter.Seq[S] {
+	return r.splitSeq(sep, len(sep))
+}
+
+// splitSeq implements SplitSeq and SplitAfterSeq, including
+// sepSave bytes of sep in the yielded pieces.
+func (r *Reader[S]) splitSeq(sep S, sepSave int) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		r.lastRead = opInvalid
+		if len(sep) == 0 {
+			for r.off < r.end() {
+				size := 1
+				if r.s[r.off] >= utf8.RuneSelf {
+					_, size = utf8.DecodeRuneInString(asString(r.unread()))
+				}
+				r.off += int64(size)
+				if !yield(r.s[r.off-int64(size) : r.off]) {
+					return
+				}
+			}
+			return
+		}
+
+		for {
+			rest := r.unread()
+			i := strings.Index(asString(rest), asString(sep))
+			if i < 0 {
+				r.off += int64(len(rest))
+				yield(rest)
+				return
+			}
+			r.off += int64(i + len(sep))
+			if !yield(rest[:i+sepSave]) {
+				return
+			}
+		}
+	}
+}
+
+// FieldsSeq returns an iterator over the fields of the unread data,
+// the maximal runs of non-white-space runes as defined by
+// unicode.IsSpace, as with strings.FieldsSeq. Nothing is yielded if the
+// unread data contains only white space. The yielded fields are views
+// into the underlying data.
+// The Reader is advanced to the end of each field before the field is
+// yielded, so stopping the iteration early leaves the Reader positioned
+// just after the last yielded field.
+func (r *Reader[S]) FieldsSeq() iter.Seq[S] { return r.FieldsFuncSeq(unicode.IsSpace) }
+
+// FieldsFuncSeq returns an iterator over the fields of the unread data,
+// the maximal runs of runes c not satisfying f(c), as with
+// strings.FieldsFuncSeq. Invalid UTF-8 is passed to f as utf8.RuneError,
+// or in strict UTF-8 mode ends the iteration, leaving the Reader
+// positioned at the invalid sequence and the field containing it, if
+// any, unyielded; Err then reports the invalid sequence.
+// The yielded fields are views into the underlying data, and the Reader
+// is consumed as with FieldsSeq.
+func (r *Reader[S]) FieldsFuncSeq(f func(rune) bool) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		r.lastRead = opInvalid
+		r.setErr(nil)
+		for {
+			// Skip leading separators.
+			for r.off < r.end() {
+				ch, size := r.peekRune()
+				if r.invalidRune(ch, size) {
+					r.setErr(&InvalidUTF8Error{Offset: r.off})
+					return
+				}
+				if !f(ch) {
+					break
+				}
+				r.off += int64(size)
+			}
+			if r.off >= r.end() {
+				return
+			}
+
+			start := r.off
+			for r.off < r.end() {
+				ch, size := r.peekRune()
+				if r.invalidRune(ch, size) {
+					r.setErr(&InvalidUTF8Error{Offset: r.off})
+					return
+				}
+				if f(ch) {
+					break
+				}
+				r.off += int64(size)
+			}
+			if !yield(r.s[start:r.off]) {
+				return
+			}
+		}
+	}
+}
+
+// IndexSeq returns an iterator over the absolute byte offsets of the
+// non-overlapping instances of sep in the unread data.
+// If sep is empty, nothing is yielded.
+// Like ReadAt, IndexSeq does not modify the Reader; the offsets are
+// those of the data at the time the iteration starts.
+func (r *Reader[S]) IndexSeq(sep S) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		if len(sep) == 0 {
+			return
+		}
+
+		s, off := asString(r.unread()), r.off
+		for {
+			i := strings.Index(s, asString(sep))
+			if i < 0 {
+				return
+			}
+			if !yield(off + int64(i)) {
+				return
+			}
+			s = s[i+len(sep):]
+			off += int64(i + len(sep))
+		}
+	}
+}
+
+// RuneStarts returns an iterator over the absolute byte offsets at which
+// each rune of the unread data starts. Each byte of an invalid UTF-8
+// sequence counts as a rune of its own, as with ReadRune.
+// RuneStarts does not modify the Reader.
+func (r *Reader[S]) RuneStarts() iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		s, off := asString(r.unread()), r.off
+		for i := 0; i < len(s); {
+			if !yield(off + int64(i)) {
+				return
+			}
+			if s[i] < utf8.RuneSelf {
+				i++
+				continue
+			}
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		}
+	}
+}
+
+// TokensSeq returns an iterator over the tokens of the unread data as
+// delimited by split, which follows the bufio.SplitFunc contract.
+// Since all of the data is in memory, split is always called with atEOF
+// set to true and there is no limit on the size of a token.
+// Tokens that split returns as sub-slices of its input are yielded as
+// views into the underlying data; other tokens are converted to S.
+// The iteration ends when split returns (0, nil, nil), when it returns
+// bufio.ErrFinalToken, or when it returns any other error, in which case
+// the Reader is left positioned at the start of the failed token and Err
+// returns the error.
+// The Reader is advanced past each token before the token is yielded.
+// It is equivalent to iterating over r.Scanner(split).
+func (r *Reader[S]) TokensSeq(split bufio.SplitFunc) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		r.setErr(nil)
+		sc := r.Scanner(split)
+		for sc.Scan() {
+			if !yield(sc.Token()) {
+				return
+			}
+		}
+		r.setErr(sc.Err())
+	}
+}
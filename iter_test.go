@@ -0,0 +1,677 @@
+package reader_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderBytes(t *testing.T) {
+	t.Parallel()
+
+	testReaderBytes(t, []byte("héllo"))
+	testReaderBytes(t, "héllo")
+}
+
+func testReaderBytes[S ~[]byte | ~string](t *testing.T, data S) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+		r := New(data)
+		var got []byte
+		for c := range r.Bytes() {
+			got = append(got, c)
+		}
+		if string(data) != string(got) {
+			t.Errorf("Bytes: got %q, want %q", got, data)
+		}
+		if r.Len() != 0 {
+			t.Errorf("Len = %d; want 0", r.Len())
+		}
+
+		r = New(data)
+		for c := range r.Bytes() {
+			if c == 'l' {
+				break
+			}
+		}
+		if rest := string(data[4:]); r.Len() != len(rest) {
+			t.Errorf("after break: Len = %d; want %d", r.Len(), len(rest))
+		}
+		if c, _ := r.ReadByte(); c != 'l' {
+			t.Errorf("after break: ReadByte = %q; want 'l'", c)
+		}
+	})
+}
+
+func TestReaderRunes(t *testing.T) {
+	t.Parallel()
+
+	testReaderRunes(t, []byte("a世\xffb"))
+	testReaderRunes(t, "a世\xffb")
+}
+
+func testReaderRunes[S ~[]byte | ~string](t *testing.T, data S) {
+	t.Helper()
+
+	type offRune struct {
+		off int64
+		ch  rune
+	}
+	want := []offRune{{0, 'a'}, {1, '世'}, {4, '�'}, {5, 'b'}}
+
+	t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+		r := New(data)
+		var got []offRune
+		for off, ch := range r.Runes() {
+			got = append(got, offRune{off, ch})
+		}
+		if fmt.Sprint(want) != fmt.Sprint(got) {
+			t.Errorf("Runes: got %v, want %v", got, want)
+		}
+
+		r = New(data)
+		for _, ch := range r.Runes() {
+			if ch == '世' {
+				break
+			}
+		}
+		if ch, size, _ := r.ReadRune(); ch != '�' || size != 1 {
+			t.Errorf("after break: ReadRune = %q, %d; want RuneError, 1", ch, size)
+		}
+
+		// Offsets are absolute, not relative to the starting position.
+		r = New(data)
+		_, _ = r.Seek(1, io.SeekStart)
+		for off, ch := range r.Runes() {
+			if off != 1 || ch != '世' {
+				t.Errorf("after Seek: got %d, %q; want 1, '世'", off, ch)
+			}
+			break
+		}
+	})
+}
+
+var runesData = bytes.Repeat([]byte("Hello, 世界! Ünïcödé ☺\n"), 1000)
+
+func BenchmarkReaderRunes(b *testing.B) {
+	b.SetBytes(int64(len(runesData)))
+	b.ReportAllocs()
+	r := New(runesData)
+	for i := 0; i < b.N; i++ {
+		r.Reset(runesData)
+		for _, ch := range r.Runes() {
+			_ = ch
+		}
+	}
+}
+
+func BenchmarkDecodeRuneLoop(b *testing.B) {
+	b.SetBytes(int64(len(runesData)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for p := runesData; len(p) > 0; {
+			ch, size := rune(p[0]), 1
+			if ch >= utf8.RuneSelf {
+				ch, size = utf8.DecodeRune(p)
+			}
+			_ = ch
+			p = p[size:]
+		}
+	}
+}
+
+func TestReaderLines(t *testing.T) {
+	t.Parallel()
+
+	testReaderLines(t, []byte("one\r\n\nthree\nfour"))
+	testReaderLines(t, "one\r\n\nthree\nfour")
+}
+
+func testReaderLines[S ~[]byte | ~string](t *testing.T, data S) {
+	t.Helper()
+
+	want := []string{"one", "", "three", "four"}
+
+	t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+		r := New(data)
+		var got []string
+		for line := range r.Lines() {
+			got = append(got, string(line))
+		}
+		if fmt.Sprintf("%q", want) != fmt.Sprintf("%q", got) {
+			t.Errorf("Lines: got %q, want %q", got, want)
+		}
+
+		r = New(data)
+		for line := range r.Lines() {
+			if len(line) == 0 {
+				break
+			}
+		}
+		if r.Len() != len("three\nfour") {
+			t.Errorf("after break: Len = %d; want %d", r.Len(), len("three\nfour"))
+		}
+	})
+}
+
+func TestReaderLinesNumbered(t *testing.T) {
+	t.Parallel()
+
+	r := New([]byte("skip\none\r\ntwo\nthree\nfour"))
+	_, _ = r.Seek(5, io.SeekStart)
+
+	var got []string
+	last := 0
+	for n, line := range r.LinesNumbered() {
+		got = append(got, fmt.Sprintf("%d:%s", n, line))
+		last = n
+		if n == 2 {
+			break
+		}
+	}
+	for n, line := range r.LinesNumberedFrom(last + 1) {
+		got = append(got, fmt.Sprintf("%d:%s", n, line))
+	}
+	if want := []string{"1:one", "2:two", "3:three", "4:four"}; fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Errorf("LinesNumbered = %q; want %q", got, want)
+	}
+}
+
+func BenchmarkReaderLines(b *testing.B) {
+	data := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog\r\n"), 1000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	r := New(data)
+	for i := 0; i < b.N; i++ {
+		r.Reset(data)
+		for line := range r.Lines() {
+			_ = line
+		}
+	}
+}
+
+func BenchmarkScannerLines(b *testing.B) {
+	data := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog\r\n"), 1000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		for sc.Scan() {
+			_ = sc.Bytes()
+		}
+	}
+}
+
+func TestReaderChunks(t *testing.T) {
+	t.Parallel()
+
+	testReaderChunks(t, []byte("0123456789"))
+	testReaderChunks(t, "0123456789")
+}
+
+func testReaderChunks[S ~[]byte | ~string](t *testing.T, data S) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+		for _, tt := range []struct {
+			size int
+			want []string
+		}{
+			{1, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}},
+			{3, []string{"012", "345", "678", "9"}},
+			{5, []string{"01234", "56789"}},
+			{20, []string{"0123456789"}},
+		} {
+			r := New(data)
+			var got []string
+			for chunk := range r.Chunks(tt.size) {
+				got = append(got, string(chunk))
+			}
+			if fmt.Sprintf("%q", tt.want) != fmt.Sprintf("%q", got) {
+				t.Errorf("Chunks(%d): got %q, want %q", tt.size, got, tt.want)
+			}
+		}
+
+		r := New(data)
+		for range r.Chunks(4) {
+			break
+		}
+		if r.Len() != 6 {
+			t.Errorf("after break: Len = %d; want 6", r.Len())
+		}
+	})
+}
+
+func TestReaderChunksReassemble(t *testing.T) {
+	t.Parallel()
+
+	testReaderChunksReassemble(t, testBytes)
+	testReaderChunksReassemble(t, testString)
+}
+
+func testReaderChunksReassemble[S ~[]byte | ~string](t *testing.T, data S) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+		for _, size := range []int{1, 7, 512, 4096, len(data), len(data) + 1} {
+			var b bytes.Buffer
+			for chunk := range New(data).Chunks(size) {
+				if len(chunk) > size {
+					t.Fatalf("Chunks(%d): chunk of %d bytes", size, len(chunk))
+				}
+				b.WriteString(string(chunk))
+			}
+			if b.String() != string(data) {
+				t.Errorf("Chunks(%d): reassembled data differs from original", size)
+			}
+		}
+	})
+}
+
+func TestReaderChunksPanic(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunks(0): expected panic")
+		}
+	}()
+	New("abc").Chunks(0)
+}
+
+func TestReaderWords(t *testing.T) {
+	t.Parallel()
+
+	testReaderWords(t, []byte("  the quick\tbrown\r\n fox  "))
+	testReaderWords(t, "  the quick\tbrown\r\n fox  ")
+}
+
+func testReaderWords[S ~[]byte | ~string](t *testing.T, data S) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+		r := New(data)
+		var got []string
+		for w := range r.Words() {
+			got = append(got, w)
+		}
+		if want := []string{"the", "quick", "brown", "fox"}; fmt.Sprintf("%q", want) != fmt.Sprintf("%q", got) {
+			t.Errorf("Words: got %q, want %q", got, want)
+		}
+		if r.Len() != 0 {
+			t.Errorf("Len = %d; want 0", r.Len())
+		}
+
+		r = New(data)
+		for w := range r.Words() {
+			if w == "quick" {
+				break
+			}
+		}
+		if c, _ := r.ReadByte(); c != 'b' {
+			t.Errorf("after break: ReadByte = %q; want 'b'", c)
+		}
+
+		for range New(data[:2]).Words() {
+			t.Error("Words over white space: yielded a word")
+		}
+	})
+}
+
+func BenchmarkReaderBytes(b *testing.B) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	r := New(data)
+	for i := 0; i < b.N; i++ {
+		r.Reset(data)
+		for c := range r.Bytes() {
+			_ = c
+		}
+	}
+}
+
+func BenchmarkReaderReadByteLoop(b *testing.B) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	r := New(data)
+	for i := 0; i < b.N; i++ {
+		r.Reset(data)
+		for {
+			c, err := r.ReadByte()
+			if err != nil {
+				break
+			}
+			_ = c
+		}
+	}
+}
+
+var splitSeqTests = []struct {
+	s, sep string
+	want   []string
+}{
+	{"a,b,c", ",", []string{"a", "b", "c"}},
+	{"a,,b", ",", []string{"a", "", "b"}},
+	{"a,b,", ",", []string{"a", "b", ""}},
+	{",", ",", []string{"", ""}},
+	{"", ",", []string{""}},
+	{"abc", ",", []string{"abc"}},
+	{"a::b::c", "::", []string{"a", "b", "c"}},
+	{"a世\xffb", "", []string{"a", "世", "\xff", "b"}},
+	{"", "", nil},
+}
+
+func TestReaderSplitSeq(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range splitSeqTests {
+		testReaderSplitSeq(t, []byte(tt.s), []byte(tt.sep), tt.want)
+		testReaderSplitSeq(t, tt.s, tt.sep, tt.want)
+	}
+}
+
+func testReaderSplitSeq[S ~[]byte | ~string](t *testing.T, data, sep S, want []string) {
+	t.Helper()
+
+	r := New(data)
+	var got []string
+	for piece := range r.SplitSeq(sep) {
+		got = append(got, string(piece))
+	}
+	if fmt.Sprintf("%q", want) != fmt.Sprintf("%q", got) {
+		t.Errorf("%T: SplitSeq(%q, %q) = %q; want %q", data, data, sep, got, want)
+	}
+	if r.Len() != 0 {
+		t.Errorf("%T: SplitSeq(%q, %q): Len = %d; want 0", data, data, sep, r.Len())
+	}
+}
+
+func TestReaderSplitSeqBreak(t *testing.T) {
+	t.Parallel()
+
+	r := New([]byte("a,b,c"))
+	for piece := range r.SplitSeq([]byte(",")) {
+		if string(piece) == "b" {
+			break
+		}
+	}
+	var rest []string
+	for piece := range r.SplitSeq([]byte(",")) {
+		rest = append(rest, string(piece))
+	}
+	if want := []string{"c"}; fmt.Sprint(want) != fmt.Sprint(rest) {
+		t.Errorf("resumed SplitSeq = %q; want %q", rest, want)
+	}
+}
+
+var splitAfterSeqTests = []struct {
+	s, sep string
+	want   []string
+}{
+	{"a,b,c", ",", []string{"a,", "b,", "c"}},
+	{"a,,b", ",", []string{"a,", ",", "b"}},
+	{"a\r\nb\r\n", "\r\n", []string{"a\r\n", "b\r\n", ""}},
+	{"", ",", []string{""}},
+	{"a世", "", []string{"a", "世"}},
+}
+
+func TestReaderSplitAfterSeq(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range splitAfterSeqTests {
+		testReaderSplitAfterSeq(t, []byte(tt.s), []byte(tt.sep), tt.want)
+		testReaderSplitAfterSeq(t, tt.s, tt.sep, tt.want)
+	}
+
+	// Concatenating the pieces reproduces the data byte-for-byte.
+	var b bytes.Buffer
+	for piece := range New(testString).SplitAfterSeq("a") {
+		b.WriteString(piece)
+	}
+	if b.String() != testString {
+		t.Error("SplitAfterSeq: concatenated pieces differ from original")
+	}
+}
+
+func testReaderSplitAfterSeq[S ~[]byte | ~string](t *testing.T, data, sep S, want []string) {
+	t.Helper()
+
+	var got []string
+	for piece := range New(data).SplitAfterSeq(sep) {
+		got = append(got, string(piece))
+	}
+	if fmt.Sprintf("%q", want) != fmt.Sprintf("%q", got) {
+		t.Errorf("%T: SplitAfterSeq(%q, %q) = %q; want %q", data, data, sep, got, want)
+	}
+}
+
+var fieldsSeqTests = []struct {
+	s    string
+	want []string
+}{
+	{"", nil},
+	{" \t\n ", nil},
+	{"abc", []string{"abc"}},
+	{"  a  bc d e\r\n", []string{"a", "bc", "d", "e"}},
+	{"\xff x", []string{"\xff", "x"}},
+	{"a\u00a0b\u2003c\u0085", []string{"a", "b", "c"}},
+}
+
+func TestReaderFieldsSeq(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range fieldsSeqTests {
+		testReaderFieldsSeq(t, []byte(tt.s), tt.want)
+		testReaderFieldsSeq(t, tt.s, tt.want)
+	}
+
+	r := New("a b  c")
+	for f := range r.FieldsSeq() {
+		if f == "b" {
+			break
+		}
+	}
+	if r.Len() != 3 {
+		t.Errorf("after break: Len = %d; want 3", r.Len())
+	}
+}
+
+func testReaderFieldsSeq[S ~[]byte | ~string](t *testing.T, data S, want []string) {
+	t.Helper()
+
+	var got []string
+	for f := range New(data).FieldsSeq() {
+		got = append(got, string(f))
+	}
+	if fmt.Sprintf("%q", want) != fmt.Sprintf("%q", got) {
+		t.Errorf("%T: FieldsSeq(%q) = %q; want %q", data, data, got, want)
+	}
+}
+
+func TestReaderFieldsFuncSeq(t *testing.T) {
+	t.Parallel()
+
+	isSep := func(c rune) bool { return c == ',' || c == ';' || c == utf8.RuneError }
+	var got []string
+	for f := range New([]byte(",a;b,,c\xffd;")).FieldsFuncSeq(isSep) {
+		got = append(got, string(f))
+	}
+	if want := []string{"a", "b", "c", "d"}; fmt.Sprintf("%q", want) != fmt.Sprintf("%q", got) {
+		t.Errorf("FieldsFuncSeq = %q; want %q", got, want)
+	}
+
+	r := New("x!y?z")
+	for f := range r.FieldsFuncSeq(unicode.IsPunct) {
+		if f == "y" {
+			break
+		}
+	}
+	var rest []string
+	for f := range r.FieldsFuncSeq(unicode.IsPunct) {
+		rest = append(rest, f)
+	}
+	if want := []string{"z"}; fmt.Sprint(want) != fmt.Sprint(rest) {
+		t.Errorf("resumed FieldsFuncSeq = %q; want %q", rest, want)
+	}
+}
+
+func TestReaderIndexSeq(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("line\nanother line\n\n"), 100)
+	data[17] = 'x'
+	r := New(data)
+	_, _ = r.Seek(3, io.SeekStart)
+
+	var want []int64
+	for i := 3; i < len(data); i++ {
+		if data[i] == '\n' {
+			want = append(want, int64(i))
+		}
+	}
+	var got []int64
+	for off := range r.IndexSeq([]byte("\n")) {
+		got = append(got, off)
+	}
+	if fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Errorf("IndexSeq(\\n) = %v; want %v", got, want)
+	}
+	if r.Len() != len(data)-3 {
+		t.Errorf("IndexSeq modified the reader: Len = %d; want %d", r.Len(), len(data)-3)
+	}
+
+	for _, tt := range []struct {
+		s, sep string
+		want   []int64
+	}{
+		{"aaaa", "aa", []int64{0, 2}},
+		{"abc", "", nil},
+		{"abc", "d", nil},
+		{"", "a", nil},
+	} {
+		var got []int64
+		for off := range New(tt.s).IndexSeq(tt.sep) {
+			got = append(got, off)
+		}
+		if fmt.Sprint(tt.want) != fmt.Sprint(got) {
+			t.Errorf("IndexSeq(%q, %q) = %v; want %v", tt.s, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestReaderRuneStarts(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "abc", "a世界\xff\xe4\xb8!", testString[:100], "\xf0\x9f\x98", "😀é"} {
+		r := New([]byte(s))
+		_, _ = r.Seek(1, io.SeekStart)
+		var offs []int64
+		for off := range r.RuneStarts() {
+			offs = append(offs, off)
+		}
+		if r.Len() != max(len(s)-1, 0) {
+			t.Errorf("RuneStarts(%q) modified the reader", s)
+		}
+
+		// The offsets partition the data into DecodeRune steps.
+		want := int64(1)
+		for i, off := range offs {
+			if want != off {
+				t.Fatalf("RuneStarts(%q)[%d] = %d; want %d", s, i, off, want)
+			}
+			_, size := utf8.DecodeRuneInString(s[off:])
+			want += int64(size)
+		}
+		if len(s) > 1 && want != int64(len(s)) {
+			t.Errorf("RuneStarts(%q) ended at %d; want %d", s, want, len(s))
+		}
+	}
+}
+
+func TestReaderTokensSeq(t *testing.T) {
+	t.Parallel()
+
+	errStop := errors.New("stop")
+	commaFinal := func(data []byte, atEOF bool) (int, []byte, error) {
+		switch i := bytes.IndexAny(data, ",."); {
+		case i < 0:
+			return 0, nil, errStop
+		case data[i] == '.':
+			return i + 1, data[:i], bufio.ErrFinalToken
+		default:
+			return i + 1, data[:i], nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		split   bufio.SplitFunc
+		s       string
+		want    []string
+		wantlen int
+		wanterr error
+	}{
+		{"ScanLines", bufio.ScanLines, "a\r\nb\n\nc", []string{"a", "b", "", "c"}, 0, nil},
+		{"ScanWords", bufio.ScanWords, "  one two\tthree  ", []string{"one", "two", "three"}, 0, nil},
+		{"ScanRunes", bufio.ScanRunes, "a世\xff", []string{"a", "世", "�"}, 0, nil},
+		{"ErrFinalToken", commaFinal, "a,b.c,d", []string{"a", "b"}, 3, nil},
+		{"error", commaFinal, "a,b,c", []string{"a", "b"}, 1, errStop},
+		{"empty", bufio.ScanLines, "", nil, 0, nil},
+	}
+
+	for _, tt := range tests {
+		for _, r := range []interface {
+			io.Reader
+			Len() int
+			Err() error
+		}{New([]byte(tt.s)), New(tt.s)} {
+			var got []string
+			switch r := r.(type) {
+			case *Reader[[]byte]:
+				for tok := range r.TokensSeq(tt.split) {
+					got = append(got, string(tok))
+				}
+			case *Reader[string]:
+				for tok := range r.TokensSeq(tt.split) {
+					got = append(got, tok)
+				}
+			}
+			if fmt.Sprintf("%q", tt.want) != fmt.Sprintf("%q", got) {
+				t.Errorf("%T: TokensSeq(%s) = %q; want %q", r, tt.name, got, tt.want)
+			}
+			if tt.wantlen != r.Len() {
+				t.Errorf("%T: TokensSeq(%s): Len = %d; want %d", r, tt.name, r.Len(), tt.wantlen)
+			}
+			if tt.wanterr != r.Err() {
+				t.Errorf("%T: TokensSeq(%s): Err = %v; want %v", r, tt.name, r.Err(), tt.wanterr)
+			}
+		}
+	}
+}
+
+func TestReaderTokensSeqZeroCopy(t *testing.T) {
+	t.Parallel()
+
+	// A token larger than bufio.MaxScanTokenSize is fine and is not copied.
+	data := append(bytes.Repeat([]byte("x"), 2*bufio.MaxScanTokenSize), "\nshort"...)
+	var toks [][]byte
+	for tok := range New(data).TokensSeq(bufio.ScanLines) {
+		toks = append(toks, tok)
+	}
+	if len(toks) != 2 || len(toks[0]) != 2*bufio.MaxScanTokenSize || string(toks[1]) != "short" {
+		t.Fatalf("TokensSeq(ScanLines): got %d tokens", len(toks))
+	}
+	if &toks[0][0] != &data[0] || &toks[1][0] != &data[len(data)-5] {
+		t.Error("TokensSeq(ScanLines): tokens are not views into the data")
+	}
+}
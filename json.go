@@ -0,0 +1,272 @@
+package reader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ReadJSONValue reads a single JSON value (object, array, string, number,
+// true, false or null) starting at the current position, after skipping
+// any leading white space, and advances the Reader to just past it.
+// Unlike json.Decoder, it reads no further than the end of the value,
+// so that other data following the value can be read from the Reader.
+// The value is checked only for well-formedness, not decoded.
+// If only white space remains, ReadJSONValue returns io.EOF; if the data
+// ends before the value does, it returns io.ErrUnexpectedEOF.
+// On any error the Reader is not advanced.
+// For a Reader[[]byte] the result is a view into the underlying data.
+func (r *Reader[S]) ReadJSONValue() (json.RawMessage, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	start := 0
+	for start < len(s) && isJSONSpace(s[start]) {
+		start++
+	}
+	if start == len(s) {
+		return nil, io.EOF
+	}
+
+	end, err := scanJSONValue(s, start)
+	if err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, err
+		}
+		return nil, &ReaderError{Method: "ReadJSONValue", Offset: r.off + int64(end), Cause: fmt.Errorf("%w: %w", ErrSyntax, err)}
+	}
+
+	v := r.unread()[start:end]
+	r.off += int64(end)
+	return json.RawMessage(v), nil
+}
+
+func isJSONSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+
+// scanJSONValue scans the JSON value starting at s[i], which must not be
+// white space, and returns the index just past it. On a syntax error it
+// returns the index of the offending byte.
+func scanJSONValue(s string, i int) (int, error) {
+	// stack holds the open containers: '{' or '['.
+	var stack []byte
+	for {
+		// Scan a single value.
+		if i >= len(s) {
+			return i, io.ErrUnexpectedEOF
+		}
+		switch c := s[i]; {
+		case c == '{' || c == '[':
+			stack = append(stack, c)
+			i++
+			i = skipJSONSpace(s, i)
+			if i >= len(s) {
+				return i, io.ErrUnexpectedEOF
+			}
+			if s[i] == c+2 { // '{'+2 == '}', '['+2 == ']'
+				stack = stack[:len(stack)-1]
+				i++
+				break
+			}
+			if c == '{' {
+				var err error
+				if i, err = scanJSONKey(s, i); err != nil {
+					return i, err
+				}
+			}
+			continue
+		case c == '"':
+			var err error
+			if i, err = scanJSONString(s, i); err != nil {
+				return i, err
+			}
+		case c == '-' || '0' <= c && c <= '9':
+			var err error
+			if i, err = scanJSONNumber(s, i); err != nil {
+				return i, err
+			}
+		case c == 't':
+			if i, err := scanJSONLiteral(s, i, "true"); err != nil {
+				return i, err
+			}
+			i += len("true")
+		case c == 'f':
+			if i, err := scanJSONLiteral(s, i, "false"); err != nil {
+				return i, err
+			}
+			i += len("false")
+		case c == 'n':
+			if i, err := scanJSONLiteral(s, i, "null"); err != nil {
+				return i, err
+			}
+			i += len("null")
+		default:
+			return i, fmt.Errorf("invalid character %q looking for beginning of value", c)
+		}
+
+		// After a value: close containers or move to the next element.
+		for {
+			if len(stack) == 0 {
+				return i, nil
+			}
+			i = skipJSONSpace(s, i)
+			if i >= len(s) {
+				return i, io.ErrUnexpectedEOF
+			}
+			top := stack[len(stack)-1]
+			if s[i] == top+2 {
+				stack = stack[:len(stack)-1]
+				i++
+				continue
+			}
+			if s[i] != ',' {
+				return i, fmt.Errorf("invalid character %q after %s element", s[i], jsonContainer(top))
+			}
+			i = skipJSONSpace(s, i+1)
+			if top == '{' {
+				var err error
+				if i, err = scanJSONKey(s, i); err != nil {
+					return i, err
+				}
+			}
+			break
+		}
+	}
+}
+
+func jsonContainer(c byte) string {
+	if c == '{' {
+		return "object"
+	}
+	return "array"
+}
+
+func skipJSONSpace(s string, i int) int {
+	for i < len(s) && isJSONSpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+// scanJSONKey scans an object key and its colon, returning the index of
+// the start of the following value.
+func scanJSONKey(s string, i int) (int, error) {
+	if i >= len(s) {
+		return i, io.ErrUnexpectedEOF
+	}
+	if s[i] != '"' {
+		return i, fmt.Errorf("invalid character %q looking for beginning of object key string", s[i])
+	}
+	i, err := scanJSONString(s, i)
+	if err != nil {
+		return i, err
+	}
+	i = skipJSONSpace(s, i)
+	if i >= len(s) {
+		return i, io.ErrUnexpectedEOF
+	}
+	if s[i] != ':' {
+		return i, fmt.Errorf("invalid character %q after object key", s[i])
+	}
+	return skipJSONSpace(s, i+1), nil
+}
+
+// scanJSONString scans the string starting with the quote at s[i].
+func scanJSONString(s string, i int) (int, error) {
+	for i++; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"':
+			return i + 1, nil
+		case c == '\\':
+			i++
+			if i >= len(s) {
+				return i, io.ErrUnexpectedEOF
+			}
+			switch s[i] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			case 'u':
+				for j := 0; j < 4; j++ {
+					i++
+					if i >= len(s) {
+						return i, io.ErrUnexpectedEOF
+					}
+					if !isHexDigit(s[i]) {
+						return i, fmt.Errorf("invalid character %q in \\u hexadecimal character escape", s[i])
+					}
+				}
+			default:
+				return i, fmt.Errorf("invalid character %q in string escape code", s[i])
+			}
+		case c < 0x20:
+			return i, fmt.Errorf("invalid character %q in string literal", c)
+		}
+	}
+	return i, io.ErrUnexpectedEOF
+}
+
+// scanJSONNumber scans the number starting at s[i].
+// Since a number has no terminator, the end of the data ends it.
+func scanJSONNumber(s string, i int) (int, error) {
+	if s[i] == '-' {
+		i++
+	}
+	switch {
+	case i >= len(s):
+		return i, io.ErrUnexpectedEOF
+	case s[i] == '0':
+		i++
+	case '1' <= s[i] && s[i] <= '9':
+		i = skipDigits(s, i)
+	default:
+		return i, fmt.Errorf("invalid character %q in numeric literal", s[i])
+	}
+
+	if i < len(s) && s[i] == '.' {
+		i++
+		if i >= len(s) {
+			return i, io.ErrUnexpectedEOF
+		}
+		if !isDigit(s[i]) {
+			return i, fmt.Errorf("invalid character %q after decimal point in numeric literal", s[i])
+		}
+		i = skipDigits(s, i)
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		if i >= len(s) {
+			return i, io.ErrUnexpectedEOF
+		}
+		if !isDigit(s[i]) {
+			return i, fmt.Errorf("invalid character %q in exponent of numeric literal", s[i])
+		}
+		i = skipDigits(s, i)
+	}
+	return i, nil
+}
+
+// scanJSONLiteral checks that s[i:] starts with lit.
+func scanJSONLiteral(s string, i int, lit string) (int, error) {
+	for j := 0; j < len(lit); j++ {
+		if i+j >= len(s) {
+			return i + j, io.ErrUnexpectedEOF
+		}
+		if s[i+j] != lit[j] {
+			return i + j, fmt.Errorf("invalid character %q in literal %s (expecting %q)", s[i+j], lit, lit[j])
+		}
+	}
+	return i, nil
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func skipDigits(s string, i int) int {
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
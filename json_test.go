@@ -0,0 +1,88 @@
+package reader_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+var readJSONValueTests = []struct {
+	s       string
+	want    string
+	wanterr any
+	wantlen int
+}{
+	{`{"a":1}rest`, `{"a":1}`, nil, 4},
+	{`  [1, 2.5e-3, -0, "x"] tail`, `[1, 2.5e-3, -0, "x"]`, nil, 5},
+	{`{"a": {"b": [true, false, null, {}]}, "c": "\"}\\é"}` + "\x00\x01", `{"a": {"b": [true, false, null, {}]}, "c": "\"}\\é"}`, nil, 2},
+	{`"str"123`, `"str"`, nil, 3},
+	{`123 456`, `123`, nil, 4},
+	{`0.5`, `0.5`, nil, 0},
+	{`[]`, `[]`, nil, 0},
+	{`null,`, `null`, nil, 1},
+	{"", "", io.EOF, 0},
+	{"  \n", "", io.EOF, 3},
+	{`{"a":`, "", io.ErrUnexpectedEOF, 5},
+	{`[1, 2`, "", io.ErrUnexpectedEOF, 5},
+	{`"abc`, "", io.ErrUnexpectedEOF, 4},
+	{`tru`, "", io.ErrUnexpectedEOF, 3},
+	{`1.`, "", io.ErrUnexpectedEOF, 2},
+	{`[1,]`, "", "reader.Reader.ReadJSONValue: syntax error: invalid character ']' looking for beginning of value at offset 3", 4},
+	{`{1:2}`, "", "reader.Reader.ReadJSONValue: syntax error: invalid character '1' looking for beginning of object key string at offset 1", 5},
+	{`{"a" 1}`, "", "reader.Reader.ReadJSONValue: syntax error: invalid character '1' after object key at offset 5", 7},
+	{`[1 2]`, "", "reader.Reader.ReadJSONValue: syntax error: invalid character '2' after array element at offset 3", 5},
+	{`"\q"`, "", "reader.Reader.ReadJSONValue: syntax error: invalid character 'q' in string escape code at offset 2", 4},
+	{`nul!`, "", "reader.Reader.ReadJSONValue: syntax error: invalid character '!' in literal null (expecting 'l') at offset 3", 4},
+	{`01`, `0`, nil, 1},
+	{`-x`, "", "reader.Reader.ReadJSONValue: syntax error: invalid character 'x' in numeric literal at offset 1", 2},
+}
+
+func TestReaderReadJSONValue(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadJSONValue[[]byte](t)
+	testReaderReadJSONValue[string](t)
+}
+
+func testReaderReadJSONValue[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range readJSONValueTests {
+			r := New(S(tt.s))
+			v, err := r.ReadJSONValue()
+			if tt.want != string(v) || fmt.Sprint(tt.wanterr) != fmt.Sprint(err) {
+				t.Errorf("ReadJSONValue(%q) = %q, %v; want %q, %v", tt.s, v, err, tt.want, tt.wanterr)
+			}
+			if tt.wantlen != r.Len() {
+				t.Errorf("ReadJSONValue(%q): Len = %d; want %d", tt.s, r.Len(), tt.wantlen)
+			}
+			if err == nil && !json.Valid(v) {
+				t.Errorf("ReadJSONValue(%q) = %q, not valid JSON", tt.s, v)
+			}
+		}
+	})
+}
+
+func TestReaderReadJSONValueStream(t *testing.T) {
+	t.Parallel()
+
+	// JSON values interleaved with binary data.
+	r := New([]byte("{\"n\":1}\x00\x01[2]\x02"))
+	for _, want := range []string{`{"n":1}`, "\x00\x01", `[2]`, "\x02"} {
+		if want[0] == '{' || want[0] == '[' {
+			v, err := r.ReadJSONValue()
+			if want != string(v) || err != nil {
+				t.Fatalf("ReadJSONValue = %q, %v; want %q, nil", v, err, want)
+			}
+			continue
+		}
+		b := make([]byte, len(want))
+		if _, err := io.ReadFull(r, b); err != nil || want != string(b) {
+			t.Fatalf("ReadFull = %q, %v; want %q, nil", b, err, want)
+		}
+	}
+}
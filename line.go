@@ -0,0 +1,158 @@
+package reader
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A LineEnding selects the line terminators recognised by ReadLine.
+type LineEnding int
+
+const (
+	// LineEndingCRLF ends lines at "\n" and strips a "\r" preceding it,
+	// as HTTP and most text protocols require. It is the default.
+	LineEndingCRLF LineEnding = iota
+	// LineEndingLF ends lines at "\n" and leaves any "\r" in the line,
+	// for data in which "\r" is not part of the line structure.
+	LineEndingLF
+	// LineEndingCR ends lines at "\r" only, as in classic Mac OS text
+	// files, and leaves any "\n" in the line.
+	LineEndingCR
+	// LineEndingAny ends lines at any of "\n", "\r\n" and a "\r" on its
+	// own, for data with mixed line endings.
+	LineEndingAny
+)
+
+// NewWithLineEnding returns a new Reader reading from s whose ReadLine
+// recognises the line terminators selected by ending. The line ending
+// affects only ReadLine and is kept by Reset.
+func NewWithLineEnding[S ~[]byte | ~string](s S, ending LineEnding) *Reader[S] {
+	r := &Reader[S]{s: s}
+	if ending != LineEndingCRLF {
+		r.options().lineEnding = ending
+	}
+	return r
+}
+
+// ReadLine reads the next line, as delimited according to the line
+// ending the Reader was created with, and advances the Reader past the
+// line and its terminator. The returned line does not include the
+// terminator and is a view into the underlying data.
+// A final line without a terminator is returned too. If no data remains,
+// ReadLine returns io.EOF.
+func (r *Reader[S]) ReadLine() (S, error) { return r.readLine(-1) }
+
+// ReadLineMax is like ReadLine but reads lines of at most limit bytes,
+// not counting the terminator. If the line is longer, ReadLineMax
+// returns its first limit bytes and io.ErrShortBuffer, advancing the
+// Reader by exactly limit bytes, so that the caller can discard the rest
+// of the line or continue reading it. It returns an error if limit is
+// negative.
+func (r *Reader[S]) ReadLineMax(limit int) (S, error) {
+	if limit < 0 {
+		r.lastRead = opInvalid
+		return r.s[:0], &ReaderError{Method: "ReadLineMax", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	return r.readLine(limit)
+}
+
+// readLine implements ReadLine and ReadLineMax, with limit < 0 meaning
+// that lines are not limited. Only the first limit+2 bytes are searched
+// for a terminator, enough for a line of limit bytes ending in "\r\n".
+func (r *Reader[S]) readLine(limit int) (S, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if len(s) == 0 {
+		return s, io.EOF
+	}
+
+	var ending LineEnding
+	if r.opts != nil {
+		ending = r.opts.lineEnding
+	}
+	seps := "\n"
+	switch ending {
+	case LineEndingCR:
+		seps = "\r"
+	case LineEndingAny:
+		seps = "\r\n"
+	}
+	window := s
+	if limit >= 0 && len(window) > limit+2 {
+		window = window[:limit+2]
+	}
+	i := strings.IndexAny(asString(window), seps)
+	n := i + 1 // bytes consumed
+	switch {
+	case i < 0:
+		i, n = len(s), len(s)
+	case ending == LineEndingAny && s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+		n++
+	case ending == LineEndingCRLF && i > 0 && s[i-1] == '\r':
+		i--
+	}
+	if limit >= 0 && i > limit {
+		r.off += int64(limit)
+		return s[:limit], io.ErrShortBuffer
+	}
+	r.off += int64(n)
+	return s[:i], nil
+}
+
+// A NewlineStyle is a kind of line terminator, as reported by
+// DetectNewline.
+type NewlineStyle int
+
+const (
+	NewlineUnknown NewlineStyle = iota // no line terminator
+	NewlineLF                          // "\n", as on Unix
+	NewlineCRLF                        // "\r\n", as on Windows and in network protocols
+	NewlineCR                          // "\r" on its own, as in classic Mac OS
+)
+
+var newlineStyleNames = [...]string{
+	NewlineUnknown: "unknown",
+	NewlineLF:      "LF",
+	NewlineCRLF:    "CRLF",
+	NewlineCR:      "CR",
+}
+
+func (n NewlineStyle) String() string {
+	if n < 0 || int(n) >= len(newlineStyleNames) {
+		return "NewlineStyle(" + strconv.Itoa(int(n)) + ")"
+	}
+	return newlineStyleNames[n]
+}
+
+// DetectNewline reports the style of the first line terminator in the
+// unread data, or NewlineUnknown if there is none, and whether any other
+// style appears as well. It stops scanning as soon as a second style is
+// found. A "\r" at the end of the data counts as NewlineCR.
+// DetectNewline does not modify the Reader.
+func (r *Reader[S]) DetectNewline() (style NewlineStyle, mixed bool) {
+	s := asString(r.unread())
+	for i := 0; ; {
+		j := strings.IndexAny(s[i:], "\r\n")
+		if j < 0 {
+			return style, false
+		}
+		i += j
+		found := NewlineLF
+		if s[i] == '\r' {
+			found = NewlineCR
+			if i+1 < len(s) && s[i+1] == '\n' {
+				found = NewlineCRLF
+				i++
+			}
+		}
+		i++
+		switch style {
+		case NewlineUnknown:
+			style = found
+		case found:
+		default:
+			return style, true
+		}
+	}
+}
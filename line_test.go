@@ -0,0 +1,188 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderReadLine(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadLine[[]byte](t)
+	testReaderReadLine[string](t)
+}
+
+func testReaderReadLine[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const data = "GET / HTTP/1.1\r\nHost: x\r\n\r\nmac\rline\nlast\r"
+	tests := []struct {
+		ending LineEnding
+		want   []string
+	}{
+		{LineEndingCRLF, []string{"GET / HTTP/1.1", "Host: x", "", "mac\rline", "last\r"}},
+		{LineEndingLF, []string{"GET / HTTP/1.1\r", "Host: x\r", "\r", "mac\rline", "last\r"}},
+		{LineEndingCR, []string{"GET / HTTP/1.1", "\nHost: x", "\n", "\nmac", "line\nlast"}},
+		{LineEndingAny, []string{"GET / HTTP/1.1", "Host: x", "", "mac", "line", "last"}},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := NewWithLineEnding(S(data), tt.ending)
+			var got []string
+			for {
+				line, err := r.ReadLine()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, string(line))
+			}
+			if fmt.Sprintf("%q", tt.want) != fmt.Sprintf("%q", got) {
+				t.Errorf("LineEnding %d: ReadLine lines = %q; want %q", tt.ending, got, tt.want)
+			}
+		}
+
+		// New defaults to LineEndingCRLF, and Reset keeps the line ending.
+		r := New(S("a\r\nb"))
+		if line, _ := r.ReadLine(); string(line) != "a" {
+			t.Errorf("default: ReadLine = %q; want %q", line, "a")
+		}
+		r = NewWithLineEnding(S(""), LineEndingCR)
+		r.Reset(S("a\rb"))
+		if line, _ := r.ReadLine(); string(line) != "a" {
+			t.Errorf("after Reset: ReadLine = %q; want %q", line, "a")
+		}
+
+		// Other methods are unaffected by the line ending.
+		r = NewWithLineEnding(S("a\rb\n"), LineEndingAny)
+		for line := range r.Lines() {
+			if string(line) != "a\rb" {
+				t.Errorf("Lines = %q; want %q", line, "a\rb")
+			}
+		}
+	})
+}
+
+func TestReaderReadLineMixedEndings(t *testing.T) {
+	t.Parallel()
+
+	const data = "unix\nwindows\r\nmac\rdouble\r\r\nblank\n\nend\r"
+	tests := []struct {
+		ending LineEnding
+		want   []string
+	}{
+		{LineEndingAny, []string{"unix", "windows", "mac", "double", "", "blank", "", "end"}},
+		{LineEndingCR, []string{"unix\nwindows", "\nmac", "double", "", "\nblank\n\nend"}},
+	}
+	for _, tt := range tests {
+		r := NewWithLineEnding(data, tt.ending)
+		var got []string
+		for {
+			line, err := r.ReadLine()
+			if err != nil {
+				break
+			}
+			got = append(got, line)
+		}
+		if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.want) {
+			t.Errorf("LineEnding %d: ReadLine lines = %q; want %q", tt.ending, got, tt.want)
+		}
+	}
+}
+
+func TestReaderReadLineMax(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadLineMax[[]byte](t)
+	testReaderReadLineMax[string](t)
+}
+
+func testReaderReadLineMax[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	type result struct {
+		line string
+		err  error
+	}
+	tests := []struct {
+		s    string
+		max  int
+		want []result
+	}{
+		{"abc\r\nde\n", 3, []result{{"abc", nil}, {"de", nil}}},
+		{"abcd\r\ne", 3, []result{{"abc", io.ErrShortBuffer}, {"d", nil}, {"e", nil}}},
+		{"abcdefg", 3, []result{{"abc", io.ErrShortBuffer}, {"def", io.ErrShortBuffer}, {"g", nil}}},
+		{"abc", 3, []result{{"abc", nil}}},
+		{"\n\n", 0, []result{{"", nil}, {"", nil}}},
+		{"a\n", 0, []result{{"", io.ErrShortBuffer}, {"", io.ErrShortBuffer}}},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			var got []result
+			for len(got) < len(tt.want) {
+				line, err := r.ReadLineMax(tt.max)
+				if err == io.EOF {
+					break
+				}
+				got = append(got, result{string(line), err})
+			}
+			if fmt.Sprint(tt.want) != fmt.Sprint(got) {
+				t.Errorf("ReadLineMax(%q, %d) = %v; want %v", tt.s, tt.max, got, tt.want)
+			}
+		}
+
+		r := New(S("abc"))
+		if line, err := r.ReadLineMax(-1); len(line) != 0 || !errors.Is(err, ErrNegativeCount) || r.Len() != 3 {
+			t.Errorf("ReadLineMax(-1) = %q, %v, Len %d; want \"\", %v, 3", line, err, r.Len(), ErrNegativeCount)
+		}
+	})
+}
+
+func TestReaderDetectNewline(t *testing.T) {
+	t.Parallel()
+
+	testReaderDetectNewline[[]byte](t)
+	testReaderDetectNewline[string](t)
+}
+
+func testReaderDetectNewline[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s     string
+		style NewlineStyle
+		mixed bool
+	}{
+		{"", NewlineUnknown, false},
+		{"no terminator", NewlineUnknown, false},
+		{"a\nb\nc", NewlineLF, false},
+		{"a\r\nb\r\n", NewlineCRLF, false},
+		{"a\rb\r", NewlineCR, false},
+		{"a\r", NewlineCR, false},
+		{"a\r\nb\nc", NewlineCRLF, true},
+		{"a\nb\r\n", NewlineLF, true},
+		{"a\rb\nc", NewlineCR, true},
+		{"a\r\rb", NewlineCR, false},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			if style, mixed := r.DetectNewline(); style != tt.style || mixed != tt.mixed {
+				t.Errorf("DetectNewline(%q) = %v, %t; want %v, %t", tt.s, style, mixed, tt.style, tt.mixed)
+			}
+			if r.Len() != len(tt.s) {
+				t.Errorf("DetectNewline(%q) modified the Reader: Len = %d", tt.s, r.Len())
+			}
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package reader
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// mapBufferSize is the size of the scratch buffer MapReader.WriteTo
+// transforms the data through.
+const mapBufferSize = 32 * 1024
+
+// A MapReader implements the io.Reader, io.ReaderAt, io.ByteReader,
+// io.Seeker and io.WriterTo interfaces by reading from a byte slice or a
+// string with every byte passed through a mapping function as it is
+// read. The underlying data is neither modified nor copied.
+// Since the mapping is byte-wise, a MapReader has no ReadRune method;
+// a mapping that alters multi-byte UTF-8 sequences need not preserve
+// their validity.
+type MapReader[S ~[]byte | ~string] struct {
+	s   S
+	off int64
+	f   func(byte) byte
+}
+
+// Map returns a MapReader reading the unread data of r with every byte
+// replaced by f applied to it, as for ASCII case folding, ROT13 or
+// stripping the high bit of legacy data. Since the mapping preserves
+// lengths, offsets, Len and Size are those of the unmapped data.
+// The MapReader is independent of r, which it does not advance.
+func (r *Reader[S]) Map(f func(byte) byte) *MapReader[S] {
+	return &MapReader[S]{s: r.unread(), f: f}
+}
+
+// Len returns the number of bytes of the unread portion of the data.
+func (r *MapReader[S]) Len() int {
+	if r.off >= int64(len(r.s)) {
+		return 0
+	}
+	return int(int64(len(r.s)) - r.off)
+}
+
+// Size returns the length of the whole data.
+func (r *MapReader[S]) Size() int64 { return int64(len(r.s)) }
+
+// mapInto fills p with the mapped bytes of s and returns their number.
+func (r *MapReader[S]) mapInto(p []byte, s S) int {
+	n := copy(p, s)
+	for i, c := range p[:n] {
+		p[i] = r.f(c)
+	}
+	return n
+}
+
+// Read implements the io.Reader interface.
+func (r *MapReader[S]) Read(p []byte) (n int, err error) {
+	if r.off >= int64(len(r.s)) {
+		return 0, io.EOF
+	}
+	n = r.mapInto(p, r.s[r.off:])
+	r.off += int64(n)
+	return n, nil
+}
+
+// ReadAt implements the io.ReaderAt interface.
+func (r *MapReader[S]) ReadAt(p []byte, off int64) (n int, err error) {
+	// cannot modify state - see io.ReaderAt
+	if off < 0 {
+		return 0, &ReaderError{Method: "MapReader.ReadAt", Offset: off, Cause: ErrNegativeOffset}
+	}
+	if off >= int64(len(r.s)) {
+		return 0, io.EOF
+	}
+	n = r.mapInto(p, r.s[off:])
+	if n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// ReadByte implements the io.ByteReader interface.
+func (r *MapReader[S]) ReadByte() (byte, error) {
+	if r.off >= int64(len(r.s)) {
+		return 0, io.EOF
+	}
+	c := r.s[r.off]
+	r.off++
+	return r.f(c), nil
+}
+
+// Seek implements the io.Seeker interface.
+func (r *MapReader[S]) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	default:
+		return 0, &ReaderError{Method: "MapReader.Seek", Offset: r.off, Cause: ErrInvalidWhence}
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += int64(len(r.s))
+	}
+
+	if offset < 0 {
+		return 0, &ReaderError{Method: "MapReader.Seek", Offset: offset, Cause: ErrNegativePosition}
+	}
+
+	r.off = offset
+	return offset, nil
+}
+
+// WriteTo implements the io.WriterTo interface. The data is mapped
+// through a scratch buffer of bounded size, not as a whole.
+func (r *MapReader[S]) WriteTo(w io.Writer) (n int64, err error) {
+	if r.off >= int64(len(r.s)) {
+		return 0, nil
+	}
+	buf := make([]byte, min(mapBufferSize, r.Len()))
+	for r.off < int64(len(r.s)) {
+		m := r.mapInto(buf, r.s[r.off:])
+		k, err := w.Write(buf[:m])
+		if k > m {
+			panic("reader.MapReader.WriteTo: invalid Write count")
+		}
+		r.off += int64(k)
+		n += int64(k)
+		if err == nil && k != m {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// A RuneMapReader implements the io.Reader, io.RuneReader and io.WriterTo
+// interfaces by reading from a byte slice or a string with every rune
+// passed through a mapping function as it is read. Since the mapping may
+// change the length of the text, a RuneMapReader has no Len, Size or
+// Seek method.
+type RuneMapReader[S ~[]byte | ~string] struct {
+	s       S
+	off     int
+	f       func(rune) rune
+	pending []byte // encoded rune partially returned by Read
+	buf     [utf8.UTFMax]byte
+}
+
+// MapRunes returns a RuneMapReader reading the unread data of r with
+// every rune replaced by f applied to it, as for case folding, and
+// dropped if f returns a negative value, as strings.Map does. An invalid
+// UTF-8 byte is passed to f as utf8.RuneError.
+// The mapped text is produced as it is read, without a transformed copy
+// of the data. The RuneMapReader is independent of r, which it does not
+// advance.
+func (r *Reader[S]) MapRunes(f func(rune) rune) *RuneMapReader[S] {
+	return &RuneMapReader[S]{s: r.unread(), f: f}
+}
+
+// next returns the next mapped rune, skipping those f drops, or false at
+// the end of the data.
+func (r *RuneMapReader[S]) next() (rune, bool) {
+	for r.off < len(r.s) {
+		ch, size := rune(r.s[r.off]), 1
+		if ch >= utf8.RuneSelf {
+			ch, size = utf8.DecodeRuneInString(asString(r.s[r.off:]))
+		}
+		r.off += size
+		if ch = r.f(ch); ch >= 0 {
+			return ch, true
+		}
+	}
+	return 0, false
+}
+
+// ReadRune implements the io.RuneReader interface, returning the next
+// mapped rune and the size of its UTF-8 encoding. The bytes of a rune
+// that Read returned only in part are returned first, one at a time, as
+// utf8.RuneError.
+func (r *RuneMapReader[S]) ReadRune() (ch rune, size int, err error) {
+	if len(r.pending) > 0 {
+		r.pending = r.pending[1:]
+		return utf8.RuneError, 1, nil
+	}
+	ch, ok := r.next()
+	if !ok {
+		return 0, 0, io.EOF
+	}
+	if !utf8.ValidRune(ch) {
+		ch = utf8.RuneError
+	}
+	return ch, utf8.RuneLen(ch), nil
+}
+
+// Read implements the io.Reader interface, filling p with the UTF-8
+// encoding of the mapped runes. A rune that does not fit in p is
+// returned in part, and the rest of it by the next call.
+func (r *RuneMapReader[S]) Read(p []byte) (n int, err error) {
+	n = copy(p, r.pending)
+	r.pending = r.pending[n:]
+	for n < len(p) {
+		ch, ok := r.next()
+		if !ok {
+			break
+		}
+		if n+utf8.UTFMax <= len(p) {
+			n += utf8.EncodeRune(p[n:], ch)
+			continue
+		}
+		m := utf8.EncodeRune(r.buf[:], ch)
+		k := copy(p[n:], r.buf[:m])
+		n += k
+		r.pending = r.buf[k:m]
+	}
+	if n == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// WriteTo implements the io.WriterTo interface. The mapped text is
+// produced through a scratch buffer of bounded size, not as a whole.
+func (r *RuneMapReader[S]) WriteTo(w io.Writer) (n int64, err error) {
+	buf := make([]byte, min(mapBufferSize, len(r.pending)+utf8.UTFMax*(len(r.s)-r.off)))
+	for {
+		m, _ := r.Read(buf)
+		if m == 0 {
+			return n, nil
+		}
+		k, err := w.Write(buf[:m])
+		if k > m {
+			panic("reader.RuneMapReader.WriteTo: invalid Write count")
+		}
+		n += int64(k)
+		if err == nil && k != m {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package reader_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func rot13(c byte) byte {
+	switch {
+	case 'a' <= c && c <= 'z':
+		return 'a' + (c-'a'+13)%26
+	case 'A' <= c && c <= 'Z':
+		return 'A' + (c-'A'+13)%26
+	}
+	return c
+}
+
+func TestMapReader(t *testing.T) {
+	t.Parallel()
+
+	testMapReader[[]byte](t)
+	testMapReader[string](t)
+}
+
+func testMapReader[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, s := range []string{"", "Hello, World!", strings.Repeat("The Quick Brown Fox. ", 4000)} {
+			want := strings.Map(func(c rune) rune { return rune(rot13(byte(c))) }, s)
+			r := New(S(s))
+
+			m := r.Map(rot13)
+			if m.Size() != int64(len(s)) || m.Len() != len(s) {
+				t.Errorf("Map(%.20q): Size = %d, Len = %d; want %d", s, m.Size(), m.Len(), len(s))
+			}
+			if err := iotest.TestReader(m, []byte(want)); err != nil {
+				t.Errorf("Map(%.20q): %v", s, err)
+			}
+
+			var b bytes.Buffer
+			if n, err := r.Map(rot13).WriteTo(&b); b.String() != want || n != int64(len(s)) || err != nil {
+				t.Errorf("Map(%.20q).WriteTo = %d, %v; want %d, nil", s, n, err, len(s))
+			}
+			if r.Len() != len(s) {
+				t.Errorf("Map(%.20q) advanced the Reader: Len = %d", s, r.Len())
+			}
+		}
+
+		r := New(S("skip HELLO"))
+		_, _ = r.Seek(5, io.SeekStart)
+		m := r.Map(func(c byte) byte { return c | 0x20 })
+		if c, err := m.ReadByte(); c != 'h' || err != nil {
+			t.Errorf("ReadByte = %q, %v; want 'h', nil", c, err)
+		}
+		if got, _ := io.ReadAll(m); string(got) != "ello" {
+			t.Errorf("ReadAll = %q; want %q", got, "ello")
+		}
+		if _, err := m.Seek(-1, io.SeekStart); err == nil {
+			t.Error("Seek(-1) succeeded; want an error")
+		}
+	})
+}
+
+// maxWriter records the largest buffer passed to Write.
+type maxWriter struct {
+	max int
+	n   int
+}
+
+func (w *maxWriter) Write(p []byte) (int, error) {
+	w.max = max(w.max, len(p))
+	w.n += len(p)
+	return len(p), nil
+}
+
+func TestMapReaderWriteToBounded(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 1<<20)
+	var w maxWriter
+	if n, err := New(data).Map(rot13).WriteTo(&w); n != int64(len(data)) || err != nil {
+		t.Fatalf("WriteTo = %d, %v; want %d, nil", n, err, len(data))
+	}
+	if w.max >= len(data) || w.n != len(data) {
+		t.Errorf("WriteTo wrote %d bytes in writes of up to %d; want bounded writes", w.n, w.max)
+	}
+}
+
+func TestRuneMapReader(t *testing.T) {
+	t.Parallel()
+
+	testRuneMapReader[[]byte](t)
+	testRuneMapReader[string](t)
+}
+
+func testRuneMapReader[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	maps := []struct {
+		name string
+		f    func(rune) rune
+	}{
+		{"identity", func(c rune) rune { return c }},
+		{"grow", func(c rune) rune {
+			if c == 'ß' {
+				return 'ẞ'
+			}
+			return unicode.ToUpper(c)
+		}},
+		{"drop", func(c rune) rune {
+			if unicode.IsSpace(c) || c == 'ß' {
+				return -1
+			}
+			return c
+		}},
+		{"shrink", func(c rune) rune {
+			if c >= utf8.RuneSelf {
+				return '?'
+			}
+			return c
+		}},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, s := range []string{"", "straße", "Größe der Straße \xff!", " \t ", strings.Repeat("ßmaß ", 10000)} {
+			for _, m := range maps {
+				want := strings.Map(m.f, s)
+				r := New(S(s))
+
+				if err := iotest.TestReader(r.MapRunes(m.f), []byte(want)); err != nil {
+					t.Errorf("MapRunes(%s) on %.20q: %v", m.name, s, err)
+				}
+
+				var got []rune
+				mr := r.MapRunes(m.f)
+				for {
+					c, size, err := mr.ReadRune()
+					if err == io.EOF {
+						break
+					}
+					if size != utf8.RuneLen(c) {
+						t.Errorf("MapRunes(%s).ReadRune = %q, %d; want size %d", m.name, c, size, utf8.RuneLen(c))
+					}
+					got = append(got, c)
+				}
+				if string(got) != want {
+					t.Errorf("MapRunes(%s) on %.20q: ReadRune yields %.20q; want %.20q", m.name, s, string(got), want)
+				}
+
+				var b bytes.Buffer
+				if n, err := r.MapRunes(m.f).WriteTo(&b); b.String() != want || n != int64(len(want)) || err != nil {
+					t.Errorf("MapRunes(%s) on %.20q: WriteTo = %d, %v; want %d, nil", m.name, s, n, err, len(want))
+				}
+				if r.Len() != len(s) {
+					t.Errorf("MapRunes(%s) advanced the Reader: Len = %d", m.name, r.Len())
+				}
+			}
+		}
+	})
+}
+
+func TestRuneMapReaderPartialRune(t *testing.T) {
+	t.Parallel()
+
+	// 'ẞ' is three bytes; a two-byte Read splits it.
+	mr := New("ß!").MapRunes(func(c rune) rune {
+		if c == 'ß' {
+			return 'ẞ'
+		}
+		return c
+	})
+	p := make([]byte, 2)
+	if n, err := mr.Read(p); n != 2 || err != nil || string(p) != "ẞ"[:2] {
+		t.Fatalf("Read = %d, %v, %q; want 2, nil, %q", n, err, p[:n], "ẞ"[:2])
+	}
+	if c, size, err := mr.ReadRune(); c != utf8.RuneError || size != 1 || err != nil {
+		t.Errorf("ReadRune after a partial rune = %q, %d, %v; want RuneError, 1, nil", c, size, err)
+	}
+	if c, _, err := mr.ReadRune(); c != '!' || err != nil {
+		t.Errorf("ReadRune = %q, %v; want '!', nil", c, err)
+	}
+}
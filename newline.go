@@ -0,0 +1,125 @@
+package reader
+
+import (
+	"io"
+	"strings"
+)
+
+// A NewlineReader implements the io.Reader, io.ByteReader and
+// io.WriterTo interfaces by reading from a byte slice or a string with
+// every "\r\n" and every bare "\r" converted to "\n" as it is read.
+// The underlying data is neither modified nor copied.
+type NewlineReader[S ~[]byte | ~string] struct {
+	s    S
+	off  int   // offset in s of the next byte to normalize
+	size int64 // normalized length of s
+	read int64 // normalized bytes read
+}
+
+// NormalizeNewlines returns a NewlineReader reading the unread data of r
+// with normalized line endings, for parsers that understand only "\n".
+// The normalized length is computed up front, by a scan of the data that
+// does not copy it, so that Len and Size are exact.
+// The NewlineReader is independent of r, which it does not advance.
+func (r *Reader[S]) NormalizeNewlines() *NewlineReader[S] {
+	s := r.unread()
+	return &NewlineReader[S]{s: s, size: int64(len(s) - strings.Count(asString(s), "\r\n"))}
+}
+
+// Len returns the number of normalized bytes not yet read.
+func (r *NewlineReader[S]) Len() int { return int(r.size - r.read) }
+
+// Size returns the normalized length of the whole data.
+func (r *NewlineReader[S]) Size() int64 { return r.size }
+
+// Read implements the io.Reader interface.
+func (r *NewlineReader[S]) Read(p []byte) (n int, err error) {
+	if r.off >= len(r.s) {
+		return 0, io.EOF
+	}
+	for n < len(p) && r.off < len(r.s) {
+		s := asString(r.s[r.off:])
+		s = s[:min(len(s), len(p)-n)]
+		i := strings.IndexByte(s, '\r')
+		if i < 0 {
+			i = len(s)
+		}
+		n += copy(p[n:], s[:i])
+		r.off += i
+		if i < len(s) {
+			// A '\r': dropped before a '\n', else read as one.
+			r.off++
+			if r.off < len(r.s) && r.s[r.off] == '\n' {
+				continue
+			}
+			p[n] = '\n'
+			n++
+		}
+	}
+	r.read += int64(n)
+	return n, nil
+}
+
+// ReadByte implements the io.ByteReader interface.
+func (r *NewlineReader[S]) ReadByte() (byte, error) {
+	if r.off >= len(r.s) {
+		return 0, io.EOF
+	}
+	c := r.s[r.off]
+	r.off++
+	if c == '\r' {
+		c = '\n'
+		if r.off < len(r.s) && r.s[r.off] == '\n' {
+			r.off++
+		}
+	}
+	r.read++
+	return c, nil
+}
+
+// lf is the line feed that WriteTo writes for a lone carriage return.
+var lf = []byte{'\n'}
+
+// WriteTo implements the io.WriterTo interface. It writes the runs of
+// data between carriage returns as they are, without copying them.
+func (r *NewlineReader[S]) WriteTo(w io.Writer) (n int64, err error) {
+	for r.off < len(r.s) {
+		s := asString(r.s[r.off:])
+		i := strings.IndexByte(s, '\r')
+		if i < 0 {
+			i = len(s)
+		}
+		if i > 0 {
+			m, err := w.Write(asBytes(s[:i]))
+			n += int64(m)
+			r.off += m
+			r.read += int64(m)
+			if err == nil && m != i {
+				err = io.ErrShortWrite
+			}
+			if err != nil {
+				return n, err
+			}
+		}
+		if i == len(s) {
+			break
+		}
+		// A '\r': dropped before a '\n', which starts the next run,
+		// else written as a '\n'.
+		if i+1 < len(s) && s[i+1] == '\n' {
+			r.off++
+			continue
+		}
+		m, err := w.Write(lf)
+		n += int64(m)
+		r.off += m
+		r.read += int64(m)
+		if err == nil && m != 1 {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,108 @@
+package reader_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+var normalizeNewlinesTests = []string{
+	"",
+	"no newline",
+	"unix\nlines\n",
+	"windows\r\nlines\r\n",
+	"mac\rlines\r",
+	"mixed\r\nand\rbare\nends\r",
+	"\r\r\n\n\r",
+	strings.Repeat("a longer line of text\r\n", 100),
+}
+
+func normalize(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+}
+
+func TestNewlineReader(t *testing.T) {
+	t.Parallel()
+
+	testNewlineReader[[]byte](t)
+	testNewlineReader[string](t)
+}
+
+func testNewlineReader[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, s := range normalizeNewlinesTests {
+			want := normalize(s)
+			r := New(S(s))
+
+			nr := r.NormalizeNewlines()
+			if nr.Size() != int64(len(want)) || nr.Len() != len(want) {
+				t.Errorf("NormalizeNewlines(%.20q): Size = %d, Len = %d; want %d", s, nr.Size(), nr.Len(), len(want))
+			}
+			if err := iotest.TestReader(nr, []byte(want)); err != nil {
+				t.Errorf("NormalizeNewlines(%.20q): %v", s, err)
+			}
+
+			// Reads one byte at a time, split across Read calls.
+			got, err := io.ReadAll(iotest.OneByteReader(r.NormalizeNewlines()))
+			if string(got) != want || err != nil {
+				t.Errorf("NormalizeNewlines(%.20q) one byte at a time = %q, %v; want %q", s, got, err, want)
+			}
+
+			nr = r.NormalizeNewlines()
+			var b bytes.Buffer
+			if n, err := nr.WriteTo(&b); b.String() != want || n != int64(len(want)) || err != nil {
+				t.Errorf("NormalizeNewlines(%.20q).WriteTo = %d, %v, %q; want %q", s, n, err, b.String(), want)
+			}
+			if nr.Len() != 0 {
+				t.Errorf("NormalizeNewlines(%.20q): Len after WriteTo = %d; want 0", s, nr.Len())
+			}
+
+			if r.Len() != len(s) {
+				t.Errorf("NormalizeNewlines(%.20q) advanced the Reader: Len = %d", s, r.Len())
+			}
+		}
+	})
+}
+
+func TestNewlineReaderReadByte(t *testing.T) {
+	t.Parallel()
+
+	nr := New("a\r\nb\rc").NormalizeNewlines()
+	var got []byte
+	for {
+		c, err := nr.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		got = append(got, c)
+		if nr.Len() != 5-len(got) {
+			t.Errorf("Len after %q = %d; want %d", got, nr.Len(), 5-len(got))
+		}
+	}
+	if string(got) != "a\nb\nc" {
+		t.Errorf("ReadByte sequence = %q; want %q", got, "a\nb\nc")
+	}
+}
+
+func TestNewlineReaderWriteToAllocs(t *testing.T) {
+	data := []byte(strings.Repeat("line\r\nold mac\rend\n", 100))
+	var w maxWriter // no WriteString
+	var readers []*NewlineReader[[]byte]
+	for range 11 { // AllocsPerRun makes one more run to warm up
+		readers = append(readers, New(data).NormalizeNewlines())
+	}
+	n := testing.AllocsPerRun(10, func() {
+		_, _ = readers[0].WriteTo(&w)
+		readers = readers[1:]
+	})
+	if n != 0 {
+		t.Errorf("WriteTo allocs = %v; want 0", n)
+	}
+}
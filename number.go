@@ -0,0 +1,270 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A NumberKind classifies a number literal found by ScanNumber.
+type NumberKind int
+
+const (
+	NumberInvalid NumberKind = iota // not a number literal
+	NumberInt                       // decimal integer, such as 42
+	NumberFloat                     // floating-point number, such as 1.5e3 or 0x1p-2
+	NumberHex                       // hexadecimal integer, such as 0xff
+	NumberOctal                     // octal integer, such as 0o17 or 017
+	NumberBinary                    // binary integer, such as 0b101
+)
+
+var numberKindNames = [...]string{
+	NumberInvalid: "invalid",
+	NumberInt:     "int",
+	NumberFloat:   "float",
+	NumberHex:     "hex",
+	NumberOctal:   "octal",
+	NumberBinary:  "binary",
+}
+
+func (k NumberKind) String() string {
+	if k < 0 || int(k) >= len(numberKindNames) {
+		return "NumberKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return numberKindNames[k]
+}
+
+// ScanNumber finds the number literal at the current position, following
+// the syntax of Go integer and floating-point literals with an optional
+// leading '+' or '-' sign, and returns its text and kind, advancing the
+// Reader past it. The value is not converted, so that the caller can
+// choose the representation, such as big.Int or a decimal type.
+// A trailing decimal point belongs to the literal, as in "1.", as do '_'
+// separators between digits. An exponent marker not followed by digits
+// is not part of the literal.
+// The returned text is a view into the underlying data, not a copy.
+// If no number starts at the current position, or the literal is
+// malformed, as in "1__0" or "09", ScanNumber returns an error wrapping
+// ErrSyntax and does not advance. If no data remains, it returns io.EOF.
+func (r *Reader[S]) ScanNumber() (text S, kind NumberKind, err error) {
+	return r.scanNumber("ScanNumber", false)
+}
+
+// ScanJSONNumber is like ScanNumber but follows the stricter JSON number
+// syntax: only a '-' sign, decimal digits without leading zeros or '_'
+// separators, and a fraction or exponent only if followed by digits.
+// The literal ends where the JSON grammar does, so that "01" yields "0"
+// and "1." yields "1". The kind is NumberInt or NumberFloat.
+func (r *Reader[S]) ScanJSONNumber() (text S, kind NumberKind, err error) {
+	return r.scanNumber("ScanJSONNumber", true)
+}
+
+// scanNumber implements ScanNumber and ScanJSONNumber.
+func (r *Reader[S]) scanNumber(method string, json bool) (S, NumberKind, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if len(s) == 0 {
+		return s, NumberInvalid, io.EOF
+	}
+
+	var n int
+	var kind NumberKind
+	if json {
+		n, kind = scanNumberJSON(asString(s))
+	} else {
+		n, kind = scanNumberGo(asString(s))
+	}
+	if kind == NumberInvalid {
+		return s[:0], NumberInvalid, &ReaderError{
+			Method: method,
+			Offset: r.off,
+			Cause:  fmt.Errorf("%w: invalid number literal %q", ErrSyntax, s[:n]),
+		}
+	}
+	r.off += int64(n)
+	return s[:n], kind, nil
+}
+
+// scanDigits returns the length of the run of digits in the given base,
+// and of '_' separators if underscores is set, at the start of s.
+func scanDigits(s string, base int, underscores bool) int {
+	i := 0
+	for ; i < len(s) && (digitVal(s[i]) < base || underscores && s[i] == '_'); i++ {
+	}
+	return i
+}
+
+// scanExponent returns the length of the exponent introduced by the
+// marker e or its upper case at the start of s, or 0 if there is none.
+func scanExponent(s string, e byte, underscores bool) int {
+	if len(s) == 0 || s[0]|0x20 != e {
+		return 0
+	}
+	i := 1
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	if n := scanDigits(s[i:], 10, underscores); n > 0 {
+		return i + n
+	}
+	return 0
+}
+
+// scanNumberGo returns the length and kind of the Go number literal,
+// with an optional sign, at the start of s. For a malformed literal the
+// kind is NumberInvalid and the length covers the text examined.
+func scanNumberGo(s string) (int, NumberKind) {
+	i := 0
+	if s[0] == '+' || s[0] == '-' {
+		i++
+	}
+
+	kind := NumberInt
+	if len(s)-i >= 2 && s[i] == '0' {
+		switch s[i+1] | 0x20 {
+		case 'x':
+			i += 2
+			n := scanDigits(s[i:], 16, true)
+			i += n
+			if i < len(s) && s[i] == '.' {
+				m := scanDigits(s[i+1:], 16, true)
+				n += m
+				i += 1 + m
+				kind = NumberFloat
+			}
+			if m := scanExponent(s[i:], 'p', true); m > 0 {
+				i += m
+				kind = NumberFloat
+			} else if kind == NumberFloat {
+				// A hexadecimal mantissa needs an exponent.
+				return i, NumberInvalid
+			}
+			if kind == NumberInt {
+				kind = NumberHex
+			}
+			return i, checkNumber(s[:i], n > 0, kind)
+		case 'o':
+			i += 2
+			n := scanDigits(s[i:], 8, true)
+			return i + n, checkNumber(s[:i+n], n > 0, NumberOctal)
+		case 'b':
+			i += 2
+			n := scanDigits(s[i:], 2, true)
+			return i + n, checkNumber(s[:i+n], n > 0, NumberBinary)
+		}
+	}
+
+	start := i
+	n := scanDigits(s[i:], 10, true)
+	i += n
+	if i < len(s) && s[i] == '.' {
+		m := scanDigits(s[i+1:], 10, true)
+		if n == 0 && m == 0 {
+			return i, NumberInvalid
+		}
+		n += m
+		i += 1 + m
+		kind = NumberFloat
+	}
+	if n == 0 {
+		return i, NumberInvalid
+	}
+	if m := scanExponent(s[i:], 'e', true); m > 0 {
+		i += m
+		kind = NumberFloat
+	}
+	if kind == NumberInt && s[start] == '0' && i-start > 1 {
+		kind = NumberOctal
+		if scanDigits(s[start:i], 8, true) != i-start {
+			return i, NumberInvalid
+		}
+	}
+	return i, checkNumber(s[:i], true, kind)
+}
+
+// checkNumber returns kind if the literal s has digits and its '_'
+// separators are placed as in Go, and NumberInvalid otherwise.
+func checkNumber(s string, digits bool, kind NumberKind) NumberKind {
+	if !digits || !underscoreOK(s) {
+		return NumberInvalid
+	}
+	return kind
+}
+
+// underscoreOK reports whether the underscores in s are allowed: each
+// must separate two digits, or a base prefix and a digit, as in Go.
+// It is adapted from the function of the same name in strconv.
+func underscoreOK(s string) bool {
+	// saw tracks the last character (class) we saw:
+	// ^ for beginning of number,
+	// 0 for a digit or base prefix,
+	// _ for an underscore,
+	// ! for none of the above.
+	saw := '^'
+	i := 0
+
+	// Optional sign.
+	if len(s) >= 1 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+
+	// Optional base prefix.
+	hex := false
+	if len(s) >= 2 && s[0] == '0' && (s[1]|0x20 == 'b' || s[1]|0x20 == 'o' || s[1]|0x20 == 'x') {
+		i = 2
+		saw = '0' // base prefix counts as a digit for "underscore as digit separator"
+		hex = s[1]|0x20 == 'x'
+	}
+
+	// Number proper.
+	for ; i < len(s); i++ {
+		// Digits are always okay.
+		if '0' <= s[i] && s[i] <= '9' || hex && 'a' <= s[i]|0x20 && s[i]|0x20 <= 'f' {
+			saw = '0'
+			continue
+		}
+		// Underscore must follow digit.
+		if s[i] == '_' {
+			if saw != '0' {
+				return false
+			}
+			saw = '_'
+			continue
+		}
+		// Underscore must also be followed by digit.
+		if saw == '_' {
+			return false
+		}
+		// Saw non-digit, non-underscore.
+		saw = '!'
+	}
+	return saw != '_'
+}
+
+// scanNumberJSON returns the length and kind of the JSON number at the
+// start of s, as defined by RFC 8259.
+func scanNumberJSON(s string) (int, NumberKind) {
+	i := 0
+	if s[0] == '-' {
+		i++
+	}
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && '1' <= s[i] && s[i] <= '9':
+		i += scanDigits(s[i:], 10, false)
+	default:
+		return i, NumberInvalid
+	}
+
+	kind := NumberInt
+	if i+1 < len(s) && s[i] == '.' && '0' <= s[i+1] && s[i+1] <= '9' {
+		i += 1 + scanDigits(s[i+1:], 10, false)
+		kind = NumberFloat
+	}
+	if n := scanExponent(s[i:], 'e', false); n > 0 {
+		i += n
+		kind = NumberFloat
+	}
+	return i, kind
+}
@@ -0,0 +1,97 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+var scanNumberTests = []struct {
+	s    string
+	json bool
+	want string
+	kind NumberKind
+	err  error
+}{
+	{"42,", false, "42", NumberInt, nil},
+	{"+42", false, "+42", NumberInt, nil},
+	{"-1_000]", false, "-1_000", NumberInt, nil},
+	{"1.", false, "1.", NumberFloat, nil},
+	{"1.x", false, "1.", NumberFloat, nil},
+	{".5e-3 ", false, ".5e-3", NumberFloat, nil},
+	{"1e", false, "1", NumberInt, nil},
+	{"1e+x", false, "1", NumberInt, nil},
+	{"6.022_140e23", false, "6.022_140e23", NumberFloat, nil},
+	{"0xFF_ff}", false, "0xFF_ff", NumberHex, nil},
+	{"0x1.8p3", false, "0x1.8p3", NumberFloat, nil},
+	{"0o17", false, "0o17", NumberOctal, nil},
+	{"017", false, "017", NumberOctal, nil},
+	{"0_17", false, "0_17", NumberOctal, nil},
+	{"0b1012", false, "0b101", NumberBinary, nil},
+	{"0", false, "0", NumberInt, nil},
+	{"09.5", false, "09.5", NumberFloat, nil},
+	{"09", false, "", NumberInvalid, ErrSyntax},
+	{"0x", false, "", NumberInvalid, ErrSyntax},
+	{"0x1.8", false, "", NumberInvalid, ErrSyntax},
+	{"1__0", false, "", NumberInvalid, ErrSyntax},
+	{"1_", false, "", NumberInvalid, ErrSyntax},
+	{"0x_1", false, "0x_1", NumberHex, nil},
+	{".", false, "", NumberInvalid, ErrSyntax},
+	{"-", false, "", NumberInvalid, ErrSyntax},
+	{"abc", false, "", NumberInvalid, ErrSyntax},
+	{"", false, "", NumberInvalid, io.EOF},
+
+	{"-42,", true, "-42", NumberInt, nil},
+	{"0.25E+2}", true, "0.25E+2", NumberFloat, nil},
+	{"01", true, "0", NumberInt, nil},
+	{"1.", true, "1", NumberInt, nil},
+	{"1e", true, "1", NumberInt, nil},
+	{"1_000", true, "1", NumberInt, nil},
+	{"0x1", true, "0", NumberInt, nil},
+	{"+1", true, "", NumberInvalid, ErrSyntax},
+	{".5", true, "", NumberInvalid, ErrSyntax},
+	{"-", true, "", NumberInvalid, ErrSyntax},
+	{"", true, "", NumberInvalid, io.EOF},
+}
+
+func TestReaderScanNumber(t *testing.T) {
+	t.Parallel()
+
+	testReaderScanNumber[[]byte](t)
+	testReaderScanNumber[string](t)
+}
+
+func testReaderScanNumber[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range scanNumberTests {
+			r := New(S(tt.s))
+			scan, name := r.ScanNumber, "ScanNumber"
+			if tt.json {
+				scan, name = r.ScanJSONNumber, "ScanJSONNumber"
+			}
+			got, kind, err := scan()
+			if string(got) != tt.want || kind != tt.kind || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+				t.Errorf("%s(%q) = %q, %v, %v; want %q, %v, %v", name, tt.s, got, kind, err, tt.want, tt.kind, tt.err)
+			}
+			if r.Len() != len(tt.s)-len(tt.want) {
+				t.Errorf("%s(%q): Len = %d; want %d", name, tt.s, r.Len(), len(tt.s)-len(tt.want))
+			}
+		}
+	})
+}
+
+func TestNumberKindString(t *testing.T) {
+	t.Parallel()
+
+	if got := NumberHex.String(); got != "hex" {
+		t.Errorf("NumberHex.String() = %q; want %q", got, "hex")
+	}
+	if got := NumberKind(42).String(); got != "NumberKind(42)" {
+		t.Errorf("NumberKind(42).String() = %q; want %q", got, "NumberKind(42)")
+	}
+}
@@ -0,0 +1,23 @@
+package reader
+
+import "unsafe"
+
+// Overlap reports whether the underlying data of a and b share memory,
+// as readers over parts of the same slice or string do, and if so
+// returns the shared range as offsets start and end within the
+// underlying data of a. Readers over empty data never overlap.
+// Overlap does not modify either Reader.
+func Overlap[S ~[]byte | ~string](a, b *Reader[S]) (start, end int64, ok bool) {
+	sa, sb := asString(a.s), asString(b.s)
+	if len(sa) == 0 || len(sb) == 0 {
+		return 0, 0, false
+	}
+
+	pa := uintptr(unsafe.Pointer(unsafe.StringData(sa)))
+	pb := uintptr(unsafe.Pointer(unsafe.StringData(sb)))
+	lo, hi := max(pa, pb), min(pa+uintptr(len(sa)), pb+uintptr(len(sb)))
+	if lo >= hi {
+		return 0, 0, false
+	}
+	return int64(lo - pa), int64(hi - pa), true
+}
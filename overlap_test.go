@@ -0,0 +1,44 @@
+package reader_test
+
+import (
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestOverlap(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("0123456789")
+	tests := []struct {
+		name       string
+		a, b       []byte
+		start, end int64
+		ok         bool
+	}{
+		{"same", data, data, 0, 10, true},
+		{"contained", data, data[2:5], 2, 5, true},
+		{"containing", data[2:5], data, 0, 3, true},
+		{"partial", data[:6], data[4:], 4, 6, true},
+		{"adjacent", data[:5], data[5:], 0, 0, false},
+		{"disjoint", data, []byte("0123456789"), 0, 0, false},
+		{"empty", data, data[3:3], 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		start, end, ok := Overlap(New(tt.a), New(tt.b))
+		if tt.start != start || tt.end != end || tt.ok != ok {
+			t.Errorf("%s: Overlap = %d, %d, %t; want %d, %d, %t", tt.name, start, end, ok, tt.start, tt.end, tt.ok)
+		}
+	}
+
+	// Readers returned by Split share the memory of their parent.
+	s := "head|body"
+	parts := New(s).Split([]byte("|"))
+	if start, end, ok := Overlap(New(s), parts[1]); start != 5 || end != 9 || !ok {
+		t.Errorf("Overlap with Split part = %d, %d, %t; want 5, 9, true", start, end, ok)
+	}
+	if _, _, ok := Overlap(parts[0], parts[1]); ok {
+		t.Errorf("Split parts overlap")
+	}
+}
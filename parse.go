@@ -0,0 +1,339 @@
+package reader
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// digitVal returns the value of c as a digit in bases up to 36, or 36
+// if c is not a digit.
+func digitVal(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c - 'a' + 10)
+	case 'A' <= c && c <= 'Z':
+		return int(c - 'A' + 10)
+	}
+	return 36
+}
+
+// scanUint returns the length of the unsigned integer literal in the
+// given base, as accepted by strconv.ParseUint, at the start of s.
+// For base 0 a base prefix and underscores are included; a prefix not
+// followed by a digit or underscore is left out. Whether the literal is
+// well formed is left to strconv.
+func scanUint(s string, base int) int {
+	i := 0
+	if base == 0 {
+		base = 10
+		if len(s) >= 2 && s[0] == '0' {
+			switch s[1] {
+			case 'x', 'X':
+				base, i = 16, 2
+			case 'o', 'O':
+				base, i = 8, 2
+			case 'b', 'B':
+				base, i = 2, 2
+			default:
+				base = 8
+			}
+			if i == 2 && (len(s) == 2 || s[2] != '_' && digitVal(s[2]) >= base) {
+				// A lone "0" followed by a letter.
+				return 1
+			}
+		}
+		for ; i < len(s) && (s[i] == '_' || digitVal(s[i]) < base); i++ {
+		}
+		return i
+	}
+
+	for ; i < len(s) && digitVal(s[i]) < base; i++ {
+	}
+	return i
+}
+
+// ParseUint parses the maximal run of digits in the given base at the
+// current position as an unsigned integer, as strconv.ParseUint does with
+// the same base and bitSize, and advances the Reader past it. For base 0
+// the base is implied by a "0x", "0o" or "0b" prefix, or "0" for octal,
+// and underscores may separate digits, as in Go integer literals.
+// Syntax and range errors are returned as a *ReaderError holding the
+// offset of the literal and wrapping the *strconv.NumError, and the Reader
+// is not advanced. If no data remains, ParseUint returns io.EOF.
+func (r *Reader[S]) ParseUint(base int, bitSize int) (uint64, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+
+	n := scanUint(s, base)
+	x, err := strconv.ParseUint(s[:n], base, bitSize)
+	if err != nil {
+		return 0, &ReaderError{Method: "ParseUint", Offset: r.off, Cause: err}
+	}
+	r.off += int64(n)
+	return x, nil
+}
+
+// ParseInt is like ParseUint but parses a signed integer, as
+// strconv.ParseInt does, with an optional leading '+' or '-' sign.
+func (r *Reader[S]) ParseInt(base int, bitSize int) (int64, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+
+	n := 0
+	if s[0] == '+' || s[0] == '-' {
+		n++
+	}
+	n += scanUint(s[n:], base)
+	x, err := strconv.ParseInt(s[:n], base, bitSize)
+	if err != nil {
+		return 0, &ReaderError{Method: "ParseInt", Offset: r.off, Cause: err}
+	}
+	r.off += int64(n)
+	return x, nil
+}
+
+// ReadOctalInt parses the maximal run of octal digits at the current
+// position, without sign or prefix, and advances the Reader past it.
+// Syntax and range errors are returned as with ParseInt, wrapping a
+// *strconv.NumError with strconv.ErrSyntax if there is no digit or
+// strconv.ErrRange if the value overflows an int64, and the Reader is not
+// advanced. If no data remains, ReadOctalInt returns io.EOF.
+func (r *Reader[S]) ReadOctalInt() (int64, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+
+	n := scanUint(s, 8)
+	x, err := strconv.ParseInt(s[:n], 8, 64)
+	if err != nil {
+		return 0, &ReaderError{Method: "ReadOctalInt", Offset: r.off, Cause: err}
+	}
+	r.off += int64(n)
+	return x, nil
+}
+
+// ReadOctalIntN parses the octal number in the next n bytes, a
+// fixed-width field as in tar headers, and advances the Reader past the
+// field. Leading spaces and trailing spaces and NULs are ignored, and a
+// field holding nothing else is 0, as archive/tar reads it.
+// If fewer than n bytes remain, ReadOctalIntN returns io.ErrUnexpectedEOF
+// and does not advance. Other errors are returned as with ReadOctalInt.
+// It returns an error if n is negative.
+func (r *Reader[S]) ReadOctalIntN(n int) (int64, error) {
+	r.lastRead = opInvalid
+	if n < 0 {
+		return 0, &ReaderError{Method: "ReadOctalIntN", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	s := asString(r.unread())
+	if n > len(s) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	field := strings.TrimRight(strings.TrimLeft(s[:n], " "), " \x00")
+	var x uint64
+	if field != "" {
+		// ParseUint rejects a sign; 63 bits keep the value in an int64.
+		var err error
+		if x, err = strconv.ParseUint(field, 8, 63); err != nil {
+			return 0, &ReaderError{Method: "ReadOctalIntN", Offset: r.off, Cause: err}
+		}
+	}
+	r.off += int64(n)
+	return int64(x), nil
+}
+
+// ReadBinaryLiteral parses a binary integer at the current position: an
+// optional "0b" or "0B" prefix followed by the maximal run of '0' and
+// '1' digits, and advances the Reader past it. It also returns the
+// number of digits, not counting the prefix, so that callers can detect
+// leading zeros. Leading white space is not skipped.
+// Syntax and range errors are returned as with ParseUint, wrapping a
+// *strconv.NumError with strconv.ErrSyntax if there is no digit or
+// strconv.ErrRange if the value needs more than 64 bits, and the Reader
+// is not advanced. If no data remains, ReadBinaryLiteral returns io.EOF.
+func (r *Reader[S]) ReadBinaryLiteral() (x uint64, digits int, err error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, 0, io.EOF
+	}
+
+	i := 0
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'b' || s[1] == 'B') {
+		i = 2
+	}
+	n := scanUint(s[i:], 2)
+	if x, err = strconv.ParseUint(s[i:i+n], 2, 64); err != nil {
+		return 0, 0, &ReaderError{Method: "ReadBinaryLiteral", Offset: r.off, Cause: err}
+	}
+	r.off += int64(i + n)
+	return x, n, nil
+}
+
+// hasPrefixFold reports whether s begins with prefix, ignoring case.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// isWordByte reports whether c is an ASCII letter, digit or '_', which
+// would continue an identifier.
+func isWordByte(c byte) bool {
+	return 'a' <= c|0x20 && c|0x20 <= 'z' || isDigit(c) || c == '_'
+}
+
+// scanFloat is the floating-point counterpart of scanUint: it returns
+// the length of the literal, as accepted by strconv.ParseFloat, at the
+// start of s. The literal is an optional sign and either "inf",
+// "infinity" or "nan" in any case, not followed by a letter, digit or
+// '_', or a decimal or hexadecimal mantissa with an optional exponent.
+// An exponent marker not followed by digits is left out.
+func scanFloat(s string) int {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	for _, word := range [...]string{"infinity", "inf", "nan"} {
+		if hasPrefixFold(s[i:], word) {
+			if n := i + len(word); n == len(s) || !isWordByte(s[n]) {
+				return n
+			}
+		}
+	}
+
+	base, exp := 10, byte('e')
+	if len(s)-i >= 2 && s[i] == '0' && (s[i+1] == 'x' || s[i+1] == 'X') {
+		base, exp = 16, 'p'
+		i += 2
+	}
+	start := i
+	for ; i < len(s) && (s[i] == '_' || digitVal(s[i]) < base); i++ {
+	}
+	if i < len(s) && s[i] == '.' {
+		for i++; i < len(s) && (s[i] == '_' || digitVal(s[i]) < base); i++ {
+		}
+	}
+	if i == start || (i == start+1 && s[start] == '.') {
+		if base == 16 {
+			// A lone "0" followed by a letter.
+			return start - 1
+		}
+		return i
+	}
+
+	if i < len(s) && (s[i]|0x20) == exp {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		k := j
+		for ; k < len(s) && (s[k] == '_' || '0' <= s[k] && s[k] <= '9'); k++ {
+		}
+		if k > j {
+			i = k
+		}
+	}
+	return i
+}
+
+// ParseFloat parses the longest floating-point literal at the current
+// position, in any form accepted by strconv.ParseFloat, including
+// hexadecimal mantissas, underscores between digits, and the special
+// values "inf", "infinity" and "nan" in any case, and advances the Reader
+// past it. The literal is converted in place, without copying, to the
+// nearest floating-point number rounded using IEEE754 unbiased rounding
+// to the precision given by bitSize.
+// The special values must not run into a letter, digit or '_', so that
+// an identifier such as "info" or "nancy" is not taken apart.
+// An exponent marker not followed by digits, as in "1e", is not part of
+// the literal, and neither is a second decimal point, as in "1.2.3".
+// Syntax and range errors are returned as a *ReaderError holding the
+// offset of the literal and wrapping the *strconv.NumError, and the Reader
+// is not advanced. If no data remains, ParseFloat returns io.EOF.
+func (r *Reader[S]) ParseFloat(bitSize int) (float64, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+
+	n := scanFloat(s)
+	f, err := strconv.ParseFloat(s[:n], bitSize)
+	if err != nil {
+		return 0, &ReaderError{Method: "ParseFloat", Offset: r.off, Cause: err}
+	}
+	r.off += int64(n)
+	return f, nil
+}
+
+// scanDecimalFloat returns the length of the decimal floating-point
+// literal at the start of s: an optional sign, digits with an optional
+// decimal point, at least one digit in all, and an optional exponent.
+// An exponent marker not followed by digits is left out.
+func scanDecimalFloat(s string) int {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	start := i
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+	}
+	digits := i - start
+	if i < len(s) && s[i] == '.' {
+		j := i + 1
+		for ; j < len(s) && '0' <= s[j] && s[j] <= '9'; j++ {
+		}
+		digits += j - i - 1
+		i = j
+	}
+	if digits == 0 {
+		return 0
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if k := j; k < len(s) && '0' <= s[k] && s[k] <= '9' {
+			for ; k < len(s) && '0' <= s[k] && s[k] <= '9'; k++ {
+			}
+			i = k
+		}
+	}
+	return i
+}
+
+// ReadFloatText parses a decimal floating-point number at the current
+// position, such as "-12.5e3", ".5" or "7.", and advances the Reader to
+// the first byte not part of it. Unlike ParseFloat, it accepts only the
+// decimal subset of the strconv.ParseFloat grammar, so that a hexadecimal
+// prefix, underscores, or words such as "nan" or "info" following the
+// number in configuration text are left unread.
+// The number is converted in place, without copying, to a float64.
+// Syntax and range errors are returned as with ParseFloat, and the
+// Reader is not advanced. If no data remains, ReadFloatText returns io.EOF.
+func (r *Reader[S]) ReadFloatText() (float64, error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+
+	n := scanDecimalFloat(s)
+	f, err := strconv.ParseFloat(s[:n], 64)
+	if err != nil {
+		return 0, &ReaderError{Method: "ReadFloatText", Offset: r.off, Cause: err}
+	}
+	r.off += int64(n)
+	return f, nil
+}
@@ -0,0 +1,374 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderParseUint(t *testing.T) {
+	t.Parallel()
+
+	testReaderParseUint[[]byte](t)
+	testReaderParseUint[string](t)
+}
+
+func testReaderParseUint[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		base    int
+		bitSize int
+		want    uint64
+		wanterr error
+		rest    string
+	}{
+		{"12345 ", 10, 64, 12345, nil, " "},
+		{"0012x", 10, 64, 12, nil, "x"},
+		{"ff/", 16, 64, 255, nil, "/"},
+		{"FFg", 16, 8, 255, nil, "g"},
+		{"777", 8, 64, 511, nil, ""},
+		{"0x_1F;", 0, 64, 31, nil, ";"},
+		{"0o17", 0, 64, 15, nil, ""},
+		{"0b1012", 0, 64, 5, nil, "2"},
+		{"017", 0, 64, 15, nil, ""},
+		{"09", 0, 64, 0, nil, "9"},
+		{"0x", 0, 64, 0, nil, "x"},
+		{"0.5", 0, 64, 0, nil, ".5"},
+		{"1_000_000,", 0, 64, 1000000, nil, ","},
+		{"18446744073709551615", 10, 64, 1<<64 - 1, nil, ""},
+		{"256", 10, 8, 0, strconv.ErrRange, "256"},
+		{"18446744073709551616", 10, 64, 0, strconv.ErrRange, "18446744073709551616"},
+		{"1__0", 0, 64, 0, strconv.ErrSyntax, "1__0"},
+		{"1_0", 10, 64, 1, nil, "_0"},
+		{"-1", 10, 64, 0, strconv.ErrSyntax, "-1"},
+		{"x", 10, 64, 0, strconv.ErrSyntax, "x"},
+		{"", 10, 64, 0, io.EOF, ""},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ParseUint(tt.base, tt.bitSize)
+			if tt.want != got || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ParseUint(%q, %d, %d) = %d, %v; want %d, %v", tt.s, tt.base, tt.bitSize, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != len(tt.rest) {
+				t.Errorf("ParseUint(%q, %d, %d): Len = %d; want %d", tt.s, tt.base, tt.bitSize, r.Len(), len(tt.rest))
+			}
+		}
+	})
+}
+
+func TestReaderParseInt(t *testing.T) {
+	t.Parallel()
+
+	testReaderParseInt[[]byte](t)
+	testReaderParseInt[string](t)
+}
+
+func testReaderParseInt[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		base    int
+		bitSize int
+		want    int64
+		wanterr error
+		rest    string
+	}{
+		{"-42,", 10, 64, -42, nil, ","},
+		{"+7", 10, 64, 7, nil, ""},
+		{"-0x_80)", 0, 8, -128, nil, ")"},
+		{"0x80", 0, 8, 0, strconv.ErrRange, "0x80"},
+		{"-9223372036854775808", 10, 64, -1 << 63, nil, ""},
+		{"-", 10, 64, 0, strconv.ErrSyntax, "-"},
+		{"--1", 10, 64, 0, strconv.ErrSyntax, "--1"},
+		{"+-1", 10, 64, 0, strconv.ErrSyntax, "+-1"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ParseInt(tt.base, tt.bitSize)
+			if tt.want != got || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ParseInt(%q, %d, %d) = %d, %v; want %d, %v", tt.s, tt.base, tt.bitSize, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != len(tt.rest) {
+				t.Errorf("ParseInt(%q, %d, %d): Len = %d; want %d", tt.s, tt.base, tt.bitSize, r.Len(), len(tt.rest))
+			}
+		}
+
+		r := New(S("ab 300"))
+		_, _ = r.Seek(3, io.SeekStart)
+		_, err := r.ParseInt(10, 8)
+		var e *ReaderError
+		var ne *strconv.NumError
+		if !errors.As(err, &e) || e.Offset != 3 || !errors.As(err, &ne) || ne.Num != "300" {
+			t.Errorf("ParseInt error = %v; want a *ReaderError at offset 3 wrapping a NumError for %q", err, "300")
+		}
+	})
+}
+
+func TestReaderReadOctalInt(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadOctalInt[[]byte](t)
+	testReaderReadOctalInt[string](t)
+}
+
+func testReaderReadOctalInt[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		want    int64
+		wanterr error
+		rest    string
+	}{
+		{"0755 ", 0o755, nil, " "},
+		{"17\x00", 0o17, nil, "\x00"},
+		{"78", 7, nil, "8"},
+		{"777777777777777777777", 1<<63 - 1, nil, ""},
+		{"1000000000000000000000", 0, strconv.ErrRange, "1000000000000000000000"},
+		{"8", 0, strconv.ErrSyntax, "8"},
+		{"-1", 0, strconv.ErrSyntax, "-1"},
+		{"", 0, io.EOF, ""},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ReadOctalInt()
+			if tt.want != got || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ReadOctalInt(%q) = %d, %v; want %d, %v", tt.s, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != len(tt.rest) {
+				t.Errorf("ReadOctalInt(%q): Len = %d; want %d", tt.s, r.Len(), len(tt.rest))
+			}
+		}
+	})
+}
+
+func TestReaderReadOctalIntN(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadOctalIntN[[]byte](t)
+	testReaderReadOctalIntN[string](t)
+}
+
+func testReaderReadOctalIntN[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		n       int
+		want    int64
+		wanterr error
+		rest    int
+	}{
+		{"0000644\x00x", 8, 0o644, nil, 1},
+		{"     17 x", 8, 0o17, nil, 1},
+		{"00000001750 x", 12, 0o1750, nil, 1},
+		{"\x00\x00\x00\x00", 4, 0, nil, 0},
+		{"        ", 8, 0, nil, 0},
+		{"", 0, 0, nil, 0},
+		{"12 4\x00", 5, 0, strconv.ErrSyntax, 5},
+		{"0009", 4, 0, strconv.ErrSyntax, 4},
+		{"+17", 3, 0, strconv.ErrSyntax, 3},
+		{"1000000000000000000000", 22, 0, strconv.ErrRange, 22},
+		{"0644", 8, 0, io.ErrUnexpectedEOF, 4},
+		{"0644", -1, 0, ErrNegativeCount, 4},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ReadOctalIntN(tt.n)
+			if tt.want != got || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ReadOctalIntN(%q, %d) = %d, %v; want %d, %v", tt.s, tt.n, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != tt.rest {
+				t.Errorf("ReadOctalIntN(%q, %d): Len = %d; want %d", tt.s, tt.n, r.Len(), tt.rest)
+			}
+		}
+	})
+}
+
+func TestReaderReadBinaryLiteral(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBinaryLiteral[[]byte](t)
+	testReaderReadBinaryLiteral[string](t)
+}
+
+func testReaderReadBinaryLiteral[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		want    uint64
+		digits  int
+		wanterr error
+		rest    string
+	}{
+		{"0b1011,", 11, 4, nil, ","},
+		{"0B0001", 1, 4, nil, ""},
+		{"1102", 6, 3, nil, "2"},
+		{"0", 0, 1, nil, ""},
+		{"0b", 0, 0, strconv.ErrSyntax, "0b"},
+		{"0b2", 0, 0, strconv.ErrSyntax, "0b2"},
+		{" 1", 0, 0, strconv.ErrSyntax, " 1"},
+		{strings.Repeat("1", 64), 1<<64 - 1, 64, nil, ""},
+		{"0b1" + strings.Repeat("0", 64), 0, 0, strconv.ErrRange, "0b1" + strings.Repeat("0", 64)},
+		{"", 0, 0, io.EOF, ""},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, digits, err := r.ReadBinaryLiteral()
+			if tt.want != got || tt.digits != digits || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ReadBinaryLiteral(%.20q) = %d, %d, %v; want %d, %d, %v", tt.s, got, digits, err, tt.want, tt.digits, tt.wanterr)
+			}
+			if r.Len() != len(tt.rest) {
+				t.Errorf("ReadBinaryLiteral(%.20q): Len = %d; want %d", tt.s, r.Len(), len(tt.rest))
+			}
+		}
+	})
+}
+
+func BenchmarkReaderParseInt(b *testing.B) {
+	b.ReportAllocs()
+	data := []byte("-1234567890 0x_dead_beef")
+	r := New(data)
+	for i := 0; i < b.N; i++ {
+		r.Reset(data)
+		if _, err := r.ParseInt(10, 64); err != nil {
+			b.Fatal(err)
+		}
+		r.SkipWhitespace()
+		if _, err := r.ParseUint(0, 64); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestReaderParseFloat(t *testing.T) {
+	t.Parallel()
+
+	testReaderParseFloat[[]byte](t)
+	testReaderParseFloat[string](t)
+}
+
+func testReaderParseFloat[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		bitSize int
+		want    float64
+		wanterr error
+		rest    string
+	}{
+		{"3.25,", 64, 3.25, nil, ","},
+		{"-1.5e3x", 64, -1500, nil, "x"},
+		{"1e", 64, 1, nil, "e"},
+		{"1e+", 64, 1, nil, "e+"},
+		{"2E-2", 64, 0.02, nil, ""},
+		{"1.2.3", 64, 1.2, nil, ".3"},
+		{"1.", 64, 1, nil, ""},
+		{".5", 64, 0.5, nil, ""},
+		{"1_000.5 ", 64, 1000.5, nil, " "},
+		{"0x1.8p1)", 64, 3, nil, ")"},
+		{"0x.8p1", 64, 1, nil, ""},
+		{"0xg", 64, 0, nil, "xg"},
+		{"0x1.8", 64, 0, strconv.ErrSyntax, "0x1.8"},
+		{"1e400", 64, 0, strconv.ErrRange, "1e400"},
+		{"1e39", 32, 0, strconv.ErrRange, "1e39"},
+		{"+Inf]", 64, math.Inf(1), nil, "]"},
+		{"-infinity", 64, math.Inf(-1), nil, ""},
+		{"NaN", 64, math.NaN(), nil, ""},
+		{"nan)", 64, math.NaN(), nil, ")"},
+		{"nano", 64, 0, strconv.ErrSyntax, "nano"},
+		{"nancy", 64, 0, strconv.ErrSyntax, "nancy"},
+		{"info", 64, 0, strconv.ErrSyntax, "info"},
+		{"-infinite", 64, 0, strconv.ErrSyntax, "-infinite"},
+		{"inf_1", 64, 0, strconv.ErrSyntax, "inf_1"},
+		{"Inf2", 64, 0, strconv.ErrSyntax, "Inf2"},
+		{"infinity.", 64, math.Inf(1), nil, "."},
+		{"+NaN", 64, 0, strconv.ErrSyntax, "+NaN"},
+		{".", 64, 0, strconv.ErrSyntax, "."},
+		{"-", 64, 0, strconv.ErrSyntax, "-"},
+		{"e5", 64, 0, strconv.ErrSyntax, "e5"},
+		{"", 64, 0, io.EOF, ""},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ParseFloat(tt.bitSize)
+			same := tt.want == got || math.IsNaN(tt.want) && math.IsNaN(got)
+			if !same || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ParseFloat(%q, %d) = %v, %v; want %v, %v", tt.s, tt.bitSize, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != len(tt.rest) {
+				t.Errorf("ParseFloat(%q, %d): Len = %d; want %d", tt.s, tt.bitSize, r.Len(), len(tt.rest))
+			}
+		}
+	})
+}
+
+func TestReaderReadFloatText(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadFloatText[[]byte](t)
+	testReaderReadFloatText[string](t)
+}
+
+func testReaderReadFloatText[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		want    float64
+		wanterr error
+		rest    string
+	}{
+		{"3.25,", 3.25, nil, ","},
+		{"-12.5e3 ", -12500, nil, " "},
+		{"+.5", 0.5, nil, ""},
+		{"7.", 7, nil, ""},
+		{"1E-2x", 0.01, nil, "x"},
+		{"2e", 2, nil, "e"},
+		{"2e+", 2, nil, "e+"},
+		{"1.2.3", 1.2, nil, ".3"},
+		{"0x10", 0, nil, "x10"},
+		{"1_000", 1, nil, "_000"},
+		{"1e400", 0, strconv.ErrRange, "1e400"},
+		{"nan", 0, strconv.ErrSyntax, "nan"},
+		{"-.e1", 0, strconv.ErrSyntax, "-.e1"},
+		{"", 0, io.EOF, ""},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			got, err := r.ReadFloatText()
+			if tt.want != got || !errors.Is(err, tt.wanterr) || (tt.wanterr == nil) != (err == nil) {
+				t.Errorf("ReadFloatText(%q) = %v, %v; want %v, %v", tt.s, got, err, tt.want, tt.wanterr)
+			}
+			if r.Len() != len(tt.rest) {
+				t.Errorf("ReadFloatText(%q): Len = %d; want %d", tt.s, r.Len(), len(tt.rest))
+			}
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package reader
+
+import "io"
+
+// PeekN returns the next n bytes without advancing the Reader or
+// otherwise changing its state. If fewer than n bytes remain, PeekN
+// returns them with io.EOF. It returns an error if n is negative.
+// For a Reader[[]byte] the result is a view into the underlying data,
+// never a copy: it must not be modified, and must not be retained past
+// a Reset if the data may be reused. For a Reader[string] the result is
+// a copy.
+func (r *Reader[S]) PeekN(n int) ([]byte, error) {
+	s, err := r.peek("PeekN", n)
+	return []byte(s), err
+}
+
+// PeekString is like PeekN but returns a string. For a Reader[string]
+// the result is a substring of the underlying data and does not
+// allocate; for a Reader[[]byte] it is a copy.
+func (r *Reader[S]) PeekString(n int) (string, error) {
+	s, err := r.peek("PeekString", n)
+	return string(s), err
+}
+
+func (r *Reader[S]) peek(method string, n int) (S, error) {
+	s := r.unread()
+	if n < 0 {
+		return s[:0], &ReaderError{Method: method, Offset: r.off, Cause: ErrNegativeCount}
+	}
+	if n > len(s) {
+		return s, io.EOF
+	}
+	return s[:n], nil
+}
@@ -0,0 +1,80 @@
+package reader_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"unsafe"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderPeek(t *testing.T) {
+	t.Parallel()
+
+	testReaderPeek[[]byte](t)
+	testReaderPeek[string](t)
+}
+
+func testReaderPeek[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("héllo"))
+		if _, _, err := r.ReadRune(); err != nil {
+			t.Fatal(err)
+		}
+		if b, err := r.PeekN(3); string(b) != "él" || err != nil {
+			t.Errorf("PeekN(3) = %q, %v; want %q, nil", b, err, "él")
+		}
+		if s, err := r.PeekString(6); s != "éllo" || err != io.EOF {
+			t.Errorf("PeekString(6) = %q, %v; want %q, EOF", s, err, "éllo")
+		}
+		if s, err := r.PeekString(0); s != "" || err != nil {
+			t.Errorf("PeekString(0) = %q, %v; want \"\", nil", s, err)
+		}
+		if _, err := r.PeekN(-1); err == nil {
+			t.Errorf("PeekN(-1) error = nil; want error")
+		}
+		// Peeking changes no state, not even the last read.
+		if err := r.UnreadRune(); err != nil {
+			t.Errorf("UnreadRune after Peek: %v", err)
+		}
+		if r.Len() != len("héllo") {
+			t.Errorf("Len = %d; want %d", r.Len(), len("héllo"))
+		}
+	})
+}
+
+func TestReaderPeekAllocs(t *testing.T) {
+	b := []byte("zero-copy lookahead")
+	br := New(b)
+	var got []byte
+	if allocs := testing.AllocsPerRun(100, func() { got, _ = br.PeekN(4) }); allocs != 0 {
+		t.Errorf("Reader[[]byte].PeekN allocs = %v; want 0", allocs)
+	}
+	if unsafe.SliceData(got) != unsafe.SliceData(b) {
+		t.Errorf("Reader[[]byte].PeekN returned a copy")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = br.PeekString(4) }); allocs > 1 {
+		t.Errorf("Reader[[]byte].PeekString allocs = %v; want at most 1", allocs)
+	}
+
+	s := "zero-copy lookahead"
+	sr := New(s)
+	var str string
+	if allocs := testing.AllocsPerRun(100, func() { str, _ = sr.PeekString(4) }); allocs != 0 {
+		t.Errorf("Reader[string].PeekString allocs = %v; want 0", allocs)
+	}
+	if unsafe.StringData(str) != unsafe.StringData(s) {
+		t.Errorf("Reader[string].PeekString returned a copy")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { got, _ = sr.PeekN(4) }); allocs > 1 {
+		t.Errorf("Reader[string].PeekN allocs = %v; want at most 1", allocs)
+	}
+	// The copy is independent of the string.
+	got[0] = 'Z'
+	if s[0] != 'z' {
+		t.Errorf("Reader[string].PeekN aliases the string")
+	}
+}
@@ -9,8 +9,9 @@
 package reader
 
 import (
-	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -23,6 +24,35 @@ type Reader[S ~[]byte | ~string] struct {
 	s        S
 	off      int64  // read at s[off]
 	lastRead readOp // last read operation, so that Unread* can work correctly.
+	tail     int64  // bytes at the end of s consumed by ReadLastRune
+	csvComma bool   // ReadCSVField consumed a comma ending the data
+
+	opts *readerOptions // optional configuration; nil for a plain Reader
+}
+
+// readerOptions holds the optional configuration of a Reader, and the
+// state of the optional features that keep any, so that a plain Reader
+// pays for them with a single pointer.
+type readerOptions struct {
+	stats        *readerStats // usage counters; nil unless EnableStats was called
+	maxFrameSize int          // limit on ReadFrame sizes; 0 means no limit
+	strictDER    bool         // reject BER encodings that are not valid DER
+	strictUTF8   bool         // report invalid UTF-8 instead of decoding it as RuneError
+	lineEnding   LineEnding   // line terminators recognised by ReadLine
+	iterErr      error        // error that ended the last iteration; see Err
+
+	// RuneCount memo: if runeCountOK, runeCount runes follow runeCountOff.
+	runeCountOK  bool
+	runeCountOff int64
+	runeCount    int64
+}
+
+// options returns the options of the Reader, allocating them on first use.
+func (r *Reader[S]) options() *readerOptions {
+	if r.opts == nil {
+		r.opts = new(readerOptions)
+	}
+	return r.opts
 }
 
 // The readOp constants describe the last action performed on
@@ -34,21 +64,40 @@ type readOp int8
 // Don't use iota for these, as the values need to correspond with the
 // names and comments, which is easier to see when being explicit.
 const (
-	opRead      readOp = -1 // Any other read operation.
-	opInvalid   readOp = 0  // Non-read operation.
-	opReadRune1 readOp = 1  // Read rune of size 1.
-	opReadRune2 readOp = 2  // Read rune of size 2.
-	opReadRune3 readOp = 3  // Read rune of size 3.
-	opReadRune4 readOp = 4  // Read rune of size 4.
+	opReadLastRune4  readOp = -8 // ReadLastRune read a rune of size 4.
+	opReadLastRune3  readOp = -7 // ReadLastRune read a rune of size 3.
+	opReadLastRune2  readOp = -6 // ReadLastRune read a rune of size 2.
+	opReadLastRune1  readOp = -5 // ReadLastRune read a rune of size 1.
+	opReadUTF16Rune4 readOp = -4 // ReadRuneUTF16 read a surrogate pair.
+	opReadUTF16Rune2 readOp = -3 // ReadRuneUTF16 read a single code unit.
+	opRead           readOp = -1 // Any other read operation.
+	opInvalid        readOp = 0  // Non-read operation.
+	opReadRune1      readOp = 1  // Read rune of size 1.
+	opReadRune2      readOp = 2  // Read rune of size 2.
+	opReadRune3      readOp = 3  // Read rune of size 3.
+	opReadRune4      readOp = 4  // Read rune of size 4.
 )
 
 // Len returns the number of bytes of the unread portion of the
 // slice or string.
 func (r *Reader[S]) Len() int {
-	if r.off >= int64(len(r.s)) {
+	if r.off >= r.end() {
 		return 0
 	}
-	return int(int64(len(r.s)) - r.off)
+	return int(r.end() - r.off)
+}
+
+// end returns the offset at which the unread data ends: the end of the
+// slice or string, less the bytes consumed from it by ReadLastRune.
+func (r *Reader[S]) end() int64 { return int64(len(r.s)) - r.tail }
+
+// unread returns the unread portion of the slice or string.
+func (r *Reader[S]) unread() S {
+	end := r.end()
+	if r.off >= end {
+		return r.s[end:end]
+	}
+	return r.s[r.off:end]
 }
 
 // Size returns the original length of the underlying byte slice or string.
@@ -59,13 +108,15 @@ func (r *Reader[S]) Size() int64 { return int64(len(r.s)) }
 
 // Read implements the io.Reader interface.
 func (r *Reader[S]) Read(p []byte) (n int, err error) {
-	if r.off >= int64(len(r.s)) {
+	if r.off >= r.end() {
+		r.stats().read(0)
 		return 0, io.EOF
 	}
 
 	r.lastRead = opInvalid
-	n = copy(p, r.s[r.off:])
+	n = copy(p, r.s[r.off:r.end()])
 	r.off += int64(n)
+	r.stats().read(n)
 	if n > 0 {
 		r.lastRead = opRead
 	}
@@ -76,37 +127,63 @@ func (r *Reader[S]) Read(p []byte) (n int, err error) {
 func (r *Reader[S]) ReadAt(p []byte, off int64) (n int, err error) {
 	// cannot modify state - see io.ReaderAt
 	if off < 0 {
-		return 0, errors.New("reader.Reader.ReadAt: negative offset")
+		return 0, &ReaderError{Method: "ReadAt", Offset: off, Cause: ErrNegativeOffset}
 	}
 
 	if off >= int64(len(r.s)) {
+		r.stats().readAt(0)
 		return 0, io.EOF
 	}
 
 	n = copy(p, r.s[off:])
+	r.stats().readAt(n)
 	if n < len(p) {
 		err = io.EOF
 	}
 	return n, err
 }
 
+// ReadAtFull reads exactly len(p) bytes into p starting at offset off,
+// as io.ReadFull does for a reader positioned there. The error is io.EOF
+// only if no bytes were read; if fewer than len(p) bytes were read, it
+// is io.ErrUnexpectedEOF. Like ReadAt, ReadAtFull does not modify the
+// state of the Reader and is safe for concurrent use.
+func (r *Reader[S]) ReadAtFull(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, &ReaderError{Method: "ReadAtFull", Offset: off, Cause: ErrNegativeOffset}
+	}
+
+	if off < int64(len(r.s)) {
+		n = copy(p, r.s[off:])
+	}
+	r.stats().readAt(n)
+	switch {
+	case n == len(p):
+		return n, nil
+	case n == 0:
+		return 0, io.EOF
+	}
+	return n, io.ErrUnexpectedEOF
+}
+
 // ReadByte implements the io.ByteReader interface.
 func (r *Reader[S]) ReadByte() (byte, error) {
 	r.lastRead = opInvalid
-	if r.off >= int64(len(r.s)) {
+	if r.off >= r.end() {
 		return 0, io.EOF
 	}
 
 	c := r.s[r.off]
 	r.off++
 	r.lastRead = opRead
+	r.stats().bytes(1)
 	return c, nil
 }
 
 // UnreadByte complements ReadByte in implementing the io.ByteScanner interface.
 func (r *Reader[S]) UnreadByte() error {
 	if r.off <= 0 {
-		return errors.New("reader.Reader.UnreadByte: at beginning of slice or string")
+		return &ReaderError{Method: "UnreadByte", Offset: r.off, Cause: ErrAtBeginning}
 	}
 
 	r.lastRead = opInvalid
@@ -115,8 +192,10 @@ func (r *Reader[S]) UnreadByte() error {
 }
 
 // ReadRune implements the io.RuneReader interface.
+// In strict UTF-8 mode, an invalid UTF-8 sequence yields an
+// *InvalidUTF8Error and the Reader is not advanced; see SetStrictUTF8.
 func (r *Reader[S]) ReadRune() (ch rune, size int, err error) {
-	if r.off >= int64(len(r.s)) {
+	if r.off >= r.end() {
 		r.lastRead = opInvalid
 		return 0, 0, io.EOF
 	}
@@ -124,12 +203,18 @@ func (r *Reader[S]) ReadRune() (ch rune, size int, err error) {
 	if c := r.s[r.off]; c < utf8.RuneSelf {
 		r.off++
 		r.lastRead = opReadRune1
+		r.stats().readRune(1)
 		return rune(c), 1, nil
 	}
 
-	ch, size = utf8.DecodeRune([]byte(r.s[r.off:]))
+	ch, size = utf8.DecodeRune([]byte(r.s[r.off:r.end()]))
+	if r.invalidRune(ch, size) {
+		r.lastRead = opInvalid
+		return 0, 0, &InvalidUTF8Error{Offset: r.off}
+	}
 	r.off += int64(size)
 	r.lastRead = readOp(size)
+	r.stats().readRune(size)
 	return ch, size, nil
 }
 
@@ -137,7 +222,7 @@ func (r *Reader[S]) ReadRune() (ch rune, size int, err error) {
 func (r *Reader[S]) UnreadRune() error {
 	switch r.lastRead {
 	default:
-		return errors.New("reader.Reader.UnreadRune: previous operation was not ReadRune")
+		return &ReaderError{Method: "UnreadRune", Offset: r.off, Cause: ErrUnreadRune}
 	case opReadRune1, opReadRune2, opReadRune3, opReadRune4:
 	}
 
@@ -149,9 +234,10 @@ func (r *Reader[S]) UnreadRune() error {
 // Seek implements the io.Seeker interface.
 func (r *Reader[S]) Seek(offset int64, whence int) (int64, error) {
 	r.lastRead = opInvalid
+	r.stats().seek()
 	switch whence {
 	default:
-		return 0, errors.New("reader.Reader.Seek: invalid whence")
+		return 0, &ReaderError{Method: "Seek", Offset: r.off, Cause: ErrInvalidWhence}
 	case io.SeekStart:
 	case io.SeekCurrent:
 		offset += r.off
@@ -160,21 +246,41 @@ func (r *Reader[S]) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	if offset < 0 {
-		return 0, errors.New("reader.Reader.Seek: negative position")
+		return 0, &ReaderError{Method: "Seek", Offset: offset, Cause: ErrNegativePosition}
 	}
 
+	if offset != r.off {
+		r.csvComma = false
+	}
 	r.off = offset
 	return offset, nil
 }
 
+// Replay moves the Reader back by n bytes, so that they are read again,
+// stopping at the beginning of the slice or string. It is like
+// r.Seek(-n, io.SeekCurrent) but clamps instead of failing.
+// It returns an error if n is negative.
+func (r *Reader[S]) Replay(n int64) error {
+	r.lastRead = opInvalid
+	if n < 0 {
+		return &ReaderError{Method: "Replay", Offset: r.off, Cause: ErrNegativeCount}
+	}
+	r.stats().seek()
+	if n > 0 {
+		r.csvComma = false
+	}
+	r.off = max(r.off-n, 0)
+	return nil
+}
+
 // WriteTo implements the io.WriterTo interface.
 func (r *Reader[S]) WriteTo(w io.Writer) (n int64, err error) {
 	r.lastRead = opInvalid
-	if r.off >= int64(len(r.s)) {
+	if r.off >= r.end() {
 		return 0, nil
 	}
 
-	s := r.s[r.off:]
+	s := r.s[r.off:r.end()]
 	m, err := w.Write([]byte(s))
 	if m > len(s) {
 		panic("reader.Reader.WriteTo: invalid Write count")
@@ -182,6 +288,7 @@ func (r *Reader[S]) WriteTo(w io.Writer) (n int64, err error) {
 
 	r.off += int64(m)
 	n = int64(m)
+	r.stats().bytes(n)
 	if len(s) != m && err == nil {
 		err = io.ErrShortWrite
 	}
@@ -189,7 +296,103 @@ func (r *Reader[S]) WriteTo(w io.Writer) (n int64, err error) {
 }
 
 // Reset resets the Reader to be reading from s.
-func (r *Reader[S]) Reset(s S) { *r = Reader[S]{s: s} }
+// Usage statistics, if enabled, and settings such as the maximum frame
+// size are kept.
+func (r *Reader[S]) Reset(s S) {
+	opts := r.opts
+	if opts != nil {
+		opts.iterErr = nil
+		opts.runeCountOK = false
+	}
+	*r = Reader[S]{s: s, opts: opts}
+}
 
 // New returns a new Reader reading from s.
 func New[S ~[]byte | ~string](s S) *Reader[S] { return &Reader[S]{s: s} }
+
+// NewConcatReader returns a new Reader reading from the concatenation of
+// a and b, such as a header and a payload held in separate buffers.
+// The data is copied once into a single new slice, so reads, ReadAt and
+// Seek work across the seam.
+func NewConcatReader[S ~[]byte | ~string](a, b S) *Reader[[]byte] {
+	s := make([]byte, len(a)+len(b))
+	n := copy(s, a)
+	copy(s[n:], b)
+	return &Reader[[]byte]{s: s}
+}
+
+// ToStringReader returns a new Reader[string] over a copy of the
+// underlying data, positioned at the same offset as r.
+// No copy is made if the underlying data is already a string.
+func (r *Reader[S]) ToStringReader() *Reader[string] {
+	return &Reader[string]{s: string(r.s), off: r.off, lastRead: r.lastRead, tail: r.tail}
+}
+
+// ToBytesReader returns a new Reader[[]byte] over a copy of the
+// underlying data, positioned at the same offset as r.
+func (r *Reader[S]) ToBytesReader() *Reader[[]byte] {
+	return &Reader[[]byte]{s: append([]byte(nil), r.s...), off: r.off, lastRead: r.lastRead, tail: r.tail}
+}
+
+// Split slices the underlying data into all segments separated by sep,
+// as with bytes.Split, and returns a Reader for each segment, in order,
+// positioned at its start. The segments are views into the underlying
+// data, not copies, so each Reader supports independent reads, Seek and
+// ReadAt. If sep is empty, Split splits after each UTF-8 sequence.
+// Split does not modify r.
+func (r *Reader[S]) Split(sep []byte) []*Reader[S] {
+	s := asString(r.s)
+	var readers []*Reader[S]
+	if len(sep) == 0 {
+		readers = make([]*Reader[S], 0, utf8.RuneCountInString(s))
+		for i := 0; i < len(s); {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			readers = append(readers, New(r.s[i:i+size]))
+			i += size
+		}
+		return readers
+	}
+
+	readers = make([]*Reader[S], 0, strings.Count(s, asString(sep))+1)
+	start := 0
+	for {
+		i := strings.Index(s[start:], asString(sep))
+		if i < 0 {
+			break
+		}
+		readers = append(readers, New(r.s[start:start+i]))
+		start += i + len(sep)
+	}
+	return append(readers, New(r.s[start:]))
+}
+
+// SubReader returns a new Reader reading from the underlying data
+// between offsets lo and hi, as a view into it rather than a copy.
+// Unlike slicing, it returns an error instead of panicking if lo is
+// negative, hi is less than lo, or hi is greater than Size.
+// SubReader does not modify r.
+func (r *Reader[S]) SubReader(lo, hi int64) (*Reader[S], error) {
+	s, err := r.slice("SubReader", lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	return New(s), nil
+}
+
+// Slice returns the underlying data between offsets lo and hi, as a
+// view into it rather than a copy, for passing a region of the data to
+// APIs that take a byte slice or a string. Bounds are checked as by
+// SubReader. Slice does not modify the Reader.
+func (r *Reader[S]) Slice(lo, hi int64) (S, error) { return r.slice("Slice", lo, hi) }
+
+// slice implements Slice and SubReader.
+func (r *Reader[S]) slice(method string, lo, hi int64) (S, error) {
+	if lo < 0 || hi < lo || hi > int64(len(r.s)) {
+		return r.s[:0], &ReaderError{
+			Method: method,
+			Offset: r.off,
+			Cause:  fmt.Errorf("%w: [%d:%d] with size %d", ErrOutOfRange, lo, hi, len(r.s)),
+		}
+	}
+	return r.s[lo:hi], nil
+}
@@ -6,10 +6,13 @@ package reader_test
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -122,6 +125,46 @@ func TestReadAfterBigSeek(t *testing.T) {
 	})
 }
 
+func TestReaderReplay(t *testing.T) {
+	t.Parallel()
+
+	testReaderReplay[[]byte](t)
+	testReaderReplay[string](t)
+}
+
+func testReaderReplay[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789"))
+		_, _ = r.Seek(6, io.SeekStart)
+		if err := r.Replay(2); err != nil {
+			t.Fatalf("Replay(2) = %v", err)
+		}
+		if b, _ := r.ReadByte(); b != '4' {
+			t.Errorf("ReadByte after Replay(2) = %q; want '4'", b)
+		}
+
+		if err := r.Replay(-1); !errors.Is(err, ErrNegativeCount) {
+			t.Errorf("Replay(-1) = %v; want %v", err, ErrNegativeCount)
+		}
+		if r.Len() != 5 {
+			t.Errorf("Len after Replay(-1) = %d; want 5", r.Len())
+		}
+
+		_, _, _ = r.ReadRune()
+		if err := r.Replay(100); err != nil {
+			t.Fatalf("Replay(100) = %v", err)
+		}
+		if r.Len() != 10 {
+			t.Errorf("Len after Replay(100) = %d; want 10", r.Len())
+		}
+		if err := r.UnreadRune(); !errors.Is(err, ErrUnreadRune) {
+			t.Errorf("UnreadRune after Replay = %v; want %v", err, ErrUnreadRune)
+		}
+	})
+}
+
 func TestReaderAt(t *testing.T) {
 	t.Parallel()
 
@@ -155,6 +198,48 @@ func TestReaderAt(t *testing.T) {
 	})
 }
 
+func TestReaderAtFull(t *testing.T) {
+	t.Parallel()
+
+	testReaderAtFull[[]byte](t)
+	testReaderAtFull[string](t)
+}
+
+func testReaderAtFull[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		off     int64
+		n       int
+		want    string
+		wanterr error
+	}{
+		{0, 10, "0123456789", nil},
+		{1, 9, "123456789", nil},
+		{1, 10, "123456789", io.ErrUnexpectedEOF},
+		{9, 2, "9", io.ErrUnexpectedEOF},
+		{10, 1, "", io.EOF},
+		{11, 10, "", io.EOF},
+		{11, 0, "", nil},
+		{-1, 0, "", ErrNegativeOffset},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789"))
+		_, _ = r.Seek(3, io.SeekStart)
+		for _, tt := range tests {
+			b := make([]byte, tt.n)
+			n, err := r.ReadAtFull(b, tt.off)
+			if got := string(b[:n]); tt.want != got || !errors.Is(err, tt.wanterr) {
+				t.Errorf("ReadAtFull(%d, %d) = %q, %v; want %q, %v", tt.n, tt.off, got, err, tt.want, tt.wanterr)
+			}
+		}
+		if r.Len() != 7 {
+			t.Errorf("Len after ReadAtFull = %d; want 7", r.Len())
+		}
+	})
+}
+
 func TestReaderAtConcurrent(t *testing.T) {
 	// Test for the race detector, to verify ReadAt doesn't mutate
 	// any state.
@@ -495,3 +580,322 @@ func TestReaderZero(t *testing.T) {
 		}
 	})
 }
+
+func TestReaderConvert(t *testing.T) {
+	t.Parallel()
+
+	testReader(t, "héllo, 世界", func(t *testing.T, r readerInterface) {
+		if _, _, err := r.ReadRune(); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := r.ReadRune(); err != nil {
+			t.Fatal(err)
+		}
+
+		type converter interface {
+			ToStringReader() *Reader[string]
+			ToBytesReader() *Reader[[]byte]
+		}
+		for _, c := range []readerInterface{r.(converter).ToStringReader(), r.(converter).ToBytesReader()} {
+			if c.Len() != r.Len() || c.Size() != r.Size() {
+				t.Errorf("%T: Len, Size = %d, %d; want %d, %d", c, c.Len(), c.Size(), r.Len(), r.Size())
+			}
+			// The last-read state is preserved too.
+			if err := c.UnreadRune(); err != nil {
+				t.Errorf("%T: UnreadRune: %v", c, err)
+			}
+			got, err := io.ReadAll(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "éllo, 世界"; want != string(got) {
+				t.Errorf("%T: ReadAll = %q; want %q", c, got, want)
+			}
+		}
+
+		// Converting to a bytes Reader never aliases the original data.
+		b := []byte("abc")
+		br := New(b).ToBytesReader()
+		b[0] = 'x'
+		if c, _ := br.ReadByte(); c != 'a' {
+			t.Errorf("ToBytesReader shares data with the original: got %q", c)
+		}
+	})
+}
+
+func TestNewConcatReader(t *testing.T) {
+	t.Parallel()
+
+	header := []byte("HDR\x05")
+	for _, r := range []*Reader[[]byte]{NewConcatReader(header, []byte("hello")), NewConcatReader("HDR\x05", "hello")} {
+		if r.Size() != 9 {
+			t.Errorf("Size = %d; want 9", r.Size())
+		}
+		// ReadAt straddling the seam.
+		buf := make([]byte, 4)
+		if n, err := r.ReadAt(buf, 2); n != 4 || err != nil || string(buf) != "R\x05he" {
+			t.Errorf("ReadAt(2) = %d, %v, %q; want 4, nil, %q", n, err, buf[:n], "R\x05he")
+		}
+		if _, err := r.Seek(3, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		got, _ := io.ReadAll(r)
+		if string(got) != "\x05hello" {
+			t.Errorf("ReadAll after Seek = %q; want %q", got, "\x05hello")
+		}
+	}
+
+	// The sources are copied, not aliased.
+	r := NewConcatReader(header, nil)
+	header[0] = 'x'
+	if c, _ := r.ReadByte(); c != 'H' {
+		t.Errorf("NewConcatReader shares data with its source: got %q", c)
+	}
+
+	if r := NewConcatReader("", ""); r.Len() != 0 {
+		t.Errorf("empty: Len = %d; want 0", r.Len())
+	}
+}
+
+func TestReaderSplit(t *testing.T) {
+	t.Parallel()
+
+	testReaderSplit[[]byte](t)
+	testReaderSplit[string](t)
+}
+
+func testReaderSplit[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s   string
+		sep string
+	}{
+		{`{"a":1}` + "\n" + `{"b":2}` + "\n", "\n"},
+		{"a--b----c", "--"},
+		{"", ","},
+		{"abc", ","},
+		{"a,b,c", ""},
+		{"héllo", ""},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			_, _ = r.ReadByte() // the position does not matter
+			readers := r.Split([]byte(tt.sep))
+
+			want := strings.Split(tt.s, tt.sep)
+			if len(readers) != len(want) {
+				t.Fatalf("Split(%q, %q) returned %d readers; want %d", tt.s, tt.sep, len(readers), len(want))
+			}
+			if tt.sep != "" && len(readers) != strings.Count(tt.s, tt.sep)+1 {
+				t.Errorf("Split(%q, %q) returned %d readers; want Count+1", tt.s, tt.sep, len(readers))
+			}
+			for i, sr := range readers {
+				got, _ := io.ReadAll(sr)
+				if want[i] != string(got) {
+					t.Errorf("Split(%q, %q)[%d] = %q; want %q", tt.s, tt.sep, i, got, want[i])
+				}
+			}
+			if r.Len() != max(len(tt.s)-1, 0) {
+				t.Errorf("Split(%q, %q) modified the Reader", tt.s, tt.sep)
+			}
+		}
+
+		// The readers are independent.
+		readers := New(S("ab|cd")).Split([]byte("|"))
+		buf := make([]byte, 1)
+		if n, _ := readers[1].ReadAt(buf, 1); n != 1 || buf[0] != 'd' {
+			t.Errorf("ReadAt(1) = %q; want %q", buf[:n], "d")
+		}
+		if c, _ := readers[0].ReadByte(); c != 'a' {
+			t.Errorf("ReadByte = %q; want 'a'", c)
+		}
+	})
+}
+
+func TestReaderSubReader(t *testing.T) {
+	t.Parallel()
+
+	testReaderSubReader[[]byte](t)
+	testReaderSubReader[string](t)
+}
+
+func testReaderSubReader[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const data = "0123456789"
+	tests := []struct {
+		lo, hi  int64
+		wanterr string
+	}{
+		{0, 10, ""},
+		{2, 5, ""},
+		{10, 10, ""},
+		{0, 0, ""},
+		{-1, 5, "reader.Reader.SubReader: range out of bounds: [-1:5] with size 10 at offset 3"},
+		{5, 4, "reader.Reader.SubReader: range out of bounds: [5:4] with size 10 at offset 3"},
+		{5, 11, "reader.Reader.SubReader: range out of bounds: [5:11] with size 10 at offset 3"},
+		{11, 12, "reader.Reader.SubReader: range out of bounds: [11:12] with size 10 at offset 3"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(data))
+		_, _ = r.Seek(3, io.SeekStart)
+		for _, tt := range tests {
+			sub, err := r.SubReader(tt.lo, tt.hi)
+			if tt.wanterr != "" {
+				if sub != nil || err == nil || err.Error() != tt.wanterr || !errors.Is(err, ErrOutOfRange) {
+					t.Errorf("SubReader(%d, %d) = %v, %v; want nil, %s", tt.lo, tt.hi, sub, err, tt.wanterr)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("SubReader(%d, %d) error = %v", tt.lo, tt.hi, err)
+			}
+			if sub.Size() != tt.hi-tt.lo || sub.Len() != int(tt.hi-tt.lo) {
+				t.Errorf("SubReader(%d, %d): Size, Len = %d, %d; want %d, %d", tt.lo, tt.hi, sub.Size(), sub.Len(), tt.hi-tt.lo, tt.hi-tt.lo)
+			}
+			if b, _ := io.ReadAll(sub); string(b) != data[tt.lo:tt.hi] {
+				t.Errorf("SubReader(%d, %d) reads %q; want %q", tt.lo, tt.hi, b, data[tt.lo:tt.hi])
+			}
+			if start, _, ok := Overlap(r, sub); tt.lo < tt.hi && (!ok || start != tt.lo) {
+				t.Errorf("SubReader(%d, %d): Overlap = %d, %t; want %d, true", tt.lo, tt.hi, start, ok, tt.lo)
+			}
+		}
+		if r.Len() != 7 {
+			t.Errorf("Len after SubReader = %d; want 7", r.Len())
+		}
+	})
+}
+
+func TestReaderSlice(t *testing.T) {
+	t.Parallel()
+
+	testReaderSlice[[]byte](t)
+	testReaderSlice[string](t)
+}
+
+func testReaderSlice[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		data := S("0123456789")
+		r := New(data)
+		_, _ = r.Seek(3, io.SeekStart)
+		_, _, _ = r.ReadRune()
+
+		got, err := r.Slice(2, 5)
+		if string(got) != "234" || err != nil {
+			t.Errorf("Slice(2, 5) = %q, %v; want \"234\", nil", got, err)
+		}
+		if start, _, ok := Overlap(r, New(got)); !ok || start != 2 {
+			t.Errorf("Slice(2, 5) is not a view into the data: Overlap = %d, %t", start, ok)
+		}
+		if got, err := r.Slice(10, 10); len(got) != 0 || err != nil {
+			t.Errorf("Slice(10, 10) = %q, %v; want \"\", nil", got, err)
+		}
+		const wanterr = "reader.Reader.Slice: range out of bounds: [5:11] with size 10 at offset 4"
+		if got, err := r.Slice(5, 11); len(got) != 0 || err == nil || err.Error() != wanterr || !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Slice(5, 11) = %q, %v; want \"\", %s", got, err, wanterr)
+		}
+
+		if r.Len() != 6 {
+			t.Errorf("Len after Slice = %d; want 6", r.Len())
+		}
+		if err := r.UnreadRune(); err != nil {
+			t.Errorf("UnreadRune after Slice: %v", err)
+		}
+	})
+}
+
+func TestReaderErrorsIs(t *testing.T) {
+	t.Parallel()
+
+	testReaderErrorsIs[[]byte](t)
+	testReaderErrorsIs[string](t)
+}
+
+func testReaderErrorsIs[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789"))
+		tests := []struct {
+			name string
+			err  error
+			want error
+		}{
+			{"ReadAt", func() error { _, err := r.ReadAt(nil, -1); return err }(), ErrNegativeOffset},
+			{"Seek whence", func() error { _, err := r.Seek(0, 3); return err }(), ErrInvalidWhence},
+			{"Seek position", func() error { _, err := r.Seek(-1, io.SeekStart); return err }(), ErrNegativePosition},
+			{"UnreadByte", r.UnreadByte(), ErrAtBeginning},
+			{"UnreadRune", r.UnreadRune(), ErrUnreadRune},
+			{"ReadNRunes", func() error { _, err := r.ReadNRunes(-1); return err }(), ErrNegativeCount},
+			{"ReadPadded", func() error { _, err := r.ReadPadded(1, 0); return err }(), ErrInvalidAlignment},
+			{"Align", func() error { _, err := r.Align(0); return err }(), ErrInvalidAlignment},
+			{"ReadBinary", r.ReadBinary(binary.BigEndian, new(int)), ErrInvalidType},
+			{"NthLineOffset", func() error { _, err := r.NthLineOffset(2); return err }(), ErrLineOutOfRange},
+			{"ReadULEB128Max", func() error { _, err := r.ReadULEB128Max(0); return err }(), ErrInvalidWidth},
+		}
+		for _, tt := range tests {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("%s: error = %v; want one wrapping %v", tt.name, tt.err, tt.want)
+			}
+		}
+
+		r = New(S("\xff\xff\xff\xff\xff\xff\xff\xff\xff\x7fzz\xff"))
+		if _, err := r.ReadUvarint(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ReadUvarint error = %v; want one wrapping %v", err, ErrOverflow)
+		}
+		_, _ = r.Seek(10, io.SeekStart)
+		if _, err := r.ReadHex(1, false); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ReadHex error = %v; want one wrapping %v", err, ErrSyntax)
+		}
+		if err := r.ValidateUTF8(); !errors.Is(err, ErrInvalidUTF8) {
+			t.Errorf("ValidateUTF8 error = %v; want one wrapping %v", err, ErrInvalidUTF8)
+		}
+		_, _ = r.Seek(0, io.SeekStart)
+		if _, err := r.ReadULEB128Max(1); !errors.Is(err, ErrTooLong) {
+			t.Errorf("ReadULEB128Max error = %v; want one wrapping %v", err, ErrTooLong)
+		}
+	})
+}
+
+func TestReaderErrorAs(t *testing.T) {
+	t.Parallel()
+
+	testReaderErrorAs[[]byte](t)
+	testReaderErrorAs[string](t)
+}
+
+func testReaderErrorAs[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("ab\xffcd"))
+		_, _ = r.Seek(1, io.SeekStart)
+		err := r.ValidateUTF8()
+		var e *ReaderError
+		if !errors.As(err, &e) {
+			t.Fatalf("ValidateUTF8 error = %v; want a *ReaderError", err)
+		}
+		if e.Method != "ValidateUTF8" || e.Offset != 2 || e.Cause != ErrInvalidUTF8 {
+			t.Errorf("ValidateUTF8 error = %+v; want {ValidateUTF8 2 %v}", *e, ErrInvalidUTF8)
+		}
+
+		_, err = r.Seek(-2, io.SeekCurrent)
+		if !errors.As(err, &e) {
+			t.Fatalf("Seek error = %v; want a *ReaderError", err)
+		}
+		if e.Method != "Seek" || e.Offset != -1 || e.Cause != ErrNegativePosition {
+			t.Errorf("Seek error = %+v; want {Seek -1 %v}", *e, ErrNegativePosition)
+		}
+
+		_, err = r.ReadAt(nil, -3)
+		if !errors.As(err, &e) || e.Method != "ReadAt" || e.Offset != -3 || e.Cause != ErrNegativeOffset {
+			t.Errorf("ReadAt error = %v; want {ReadAt -3 %v}", err, ErrNegativeOffset)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package reader
+
+import (
+	"iter"
+	"regexp"
+	"strings"
+)
+
+// MatchSeq returns an iterator over the successive non-overlapping
+// matches of re in the unread data, yielding the absolute start and end
+// offsets of each match, exactly as regexp.Regexp.FindAllIndex reports
+// them. The matches are found in batches of doubling size, so stopping
+// the iteration early stops the search after at most about twice the
+// matches yielded.
+// MatchSeq operates on the underlying data directly and does not modify
+// the Reader; the offsets are those of the data at the time the
+// iteration starts.
+func (r *Reader[S]) MatchSeq(re *regexp.Regexp) iter.Seq[[2]int64] {
+	return func(yield func([2]int64) bool) {
+		off, s := r.off, asString(r.unread())
+		done := 0
+		for n := 4; ; n *= 2 {
+			// Each batch repeats the search from the start of the data,
+			// so that ^ and \b see the same context as FindAllIndex.
+			locs := re.FindAllStringIndex(s, n)
+			for _, loc := range locs[done:] {
+				if !yield([2]int64{off + int64(loc[0]), off + int64(loc[1])}) {
+					return
+				}
+			}
+			if len(locs) < n {
+				return
+			}
+			done = len(locs)
+		}
+	}
+}
+
+// ReadRegexpMatch reads the match of re that starts at the current
+// position, advancing the Reader past it. The match is the one re would
+// report, so it is the longest match only if re.Longest has been called.
+// If re does not match at the current position, ReadRegexpMatch returns
+// nil, nil and the Reader is not advanced.
+// For a Reader[[]byte] the returned slice is a view into the underlying data.
+//
+// re must begin with \A or ^ and have no top-level alternation, as
+// in `\A[0-9]+` or `\A(?:if|else)`, so that the search
+// stops as soon as a match at the current position is ruled out rather
+// than running through all of the remaining data; otherwise
+// ReadRegexpMatch returns an error wrapping ErrUnanchored.
+func (r *Reader[S]) ReadRegexpMatch(re *regexp.Regexp) ([]byte, error) {
+	r.lastRead = opInvalid
+	if !anchored(re.String()) {
+		return nil, &ReaderError{Method: "ReadRegexpMatch", Offset: r.off, Cause: ErrUnanchored}
+	}
+	rest := r.unread()
+	loc := re.FindStringIndex(asString(rest))
+	if loc == nil || loc[0] != 0 {
+		return nil, nil
+	}
+
+	r.off += int64(loc[1])
+	return []byte(rest[:loc[1]]), nil
+}
+
+// anchored reports whether the regular expression expr only matches at
+// the start of the text: whether it begins with \A or ^ and has no '|'
+// outside parentheses, which would give an unanchored alternative.
+func anchored(expr string) bool {
+	if !strings.HasPrefix(expr, `\A`) && !strings.HasPrefix(expr, "^") {
+		return false
+	}
+	depth := 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			i++
+		case '[':
+			// Skip the character class, in which a leading ']' is literal.
+			i++
+			if i < len(expr) && expr[i] == '^' {
+				i++
+			}
+			if i < len(expr) && expr[i] == ']' {
+				i++
+			}
+			for ; i < len(expr) && expr[i] != ']'; i++ {
+				if expr[i] == '\\' {
+					i++
+				}
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
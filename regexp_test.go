@@ -0,0 +1,135 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderMatchSeq(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		re   string
+		s    string
+		off  int64
+		want [][2]int64
+	}{
+		{`\d+`, "a1 b22 c333", 0, [][2]int64{{1, 2}, {4, 6}, {8, 11}}},
+		{`\d+`, "a1 b22 c333", 5, [][2]int64{{5, 6}, {8, 11}}},
+		{`x*`, "abc", 0, [][2]int64{{0, 0}, {1, 1}, {2, 2}, {3, 3}}},
+		{`a*`, "baaac", 0, [][2]int64{{0, 0}, {1, 4}, {5, 5}}},
+		{`世*`, "世界", 0, [][2]int64{{0, 3}, {6, 6}}},
+		{`z`, "abc", 0, nil},
+		{`^a`, "aaa", 0, [][2]int64{{0, 1}}},
+		{`\bfoo`, "foofoo", 0, [][2]int64{{0, 3}}},
+		{`\bx`, "x x x x x x x x x x", 0, [][2]int64{{0, 1}, {2, 3}, {4, 5}, {6, 7}, {8, 9}, {10, 11}, {12, 13}, {14, 15}, {16, 17}, {18, 19}}},
+	}
+
+	for _, tt := range tests {
+		re := regexp.MustCompile(tt.re)
+		br, sr := New([]byte(tt.s)), New(tt.s)
+		_, _ = br.Seek(tt.off, io.SeekStart)
+		_, _ = sr.Seek(tt.off, io.SeekStart)
+
+		var bgot, sgot [][2]int64
+		for loc := range br.MatchSeq(re) {
+			bgot = append(bgot, loc)
+		}
+		for loc := range sr.MatchSeq(re) {
+			sgot = append(sgot, loc)
+		}
+		if fmt.Sprint(tt.want) != fmt.Sprint(bgot) {
+			t.Errorf("[]byte: MatchSeq(%q) over %q = %v; want %v", tt.re, tt.s[tt.off:], bgot, tt.want)
+		}
+		if fmt.Sprint(tt.want) != fmt.Sprint(sgot) {
+			t.Errorf("string: MatchSeq(%q) over %q = %v; want %v", tt.re, tt.s[tt.off:], sgot, tt.want)
+		}
+		if br.Len() != len(tt.s)-int(tt.off) || sr.Len() != len(tt.s)-int(tt.off) {
+			t.Errorf("MatchSeq(%q) modified the reader", tt.re)
+		}
+	}
+}
+
+func TestReaderMatchSeqBreak(t *testing.T) {
+	t.Parallel()
+
+	re := regexp.MustCompile(`\d+`)
+	r := New(strings.Repeat("a1 ", 1000))
+	var got [][2]int64
+	for loc := range r.MatchSeq(re) {
+		got = append(got, loc)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := [][2]int64{{1, 2}, {4, 5}}; fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Errorf("MatchSeq with break = %v; want %v", got, want)
+	}
+}
+
+func TestReaderReadRegexpMatch(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadRegexpMatch[[]byte](t)
+	testReaderReadRegexpMatch[string](t)
+}
+
+func testReaderReadRegexpMatch[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	ident := regexp.MustCompile(`\A[A-Za-z_]\w*`)
+	number := regexp.MustCompile(`^\d+`)
+	empty := regexp.MustCompile(`\Ax*`)
+	keyword := regexp.MustCompile(`\A(?:if|[|\]]|\|)`)
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("foo 42 bar"))
+		for _, tt := range []struct {
+			re      *regexp.Regexp
+			want    string
+			wantnil bool
+			wantlen int
+		}{
+			{ident, "foo", false, 7},
+			{ident, "", true, 7},
+			{number, "", true, 7},
+			{empty, "", false, 7}, // empty match at the current position
+			{keyword, "", true, 7},
+		} {
+			got, err := r.ReadRegexpMatch(tt.re)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want != string(got) || tt.wantnil != (got == nil) {
+				t.Errorf("ReadRegexpMatch(%v) = %q; want %q", tt.re, got, tt.want)
+			}
+			if tt.wantlen != r.Len() {
+				t.Errorf("ReadRegexpMatch(%v): Len = %d; want %d", tt.re, r.Len(), tt.wantlen)
+			}
+		}
+
+		r.SkipWhitespace()
+		if got, err := r.ReadRegexpMatch(number); string(got) != "42" || err != nil {
+			t.Errorf("ReadRegexpMatch(%v) = %q, %v; want \"42\", nil", number, got, err)
+		}
+		if got, err := r.ReadRegexpMatch(ident); got != nil || err != nil {
+			t.Errorf("ReadRegexpMatch(%v) before space = %q, %v; want nil, nil", ident, got, err)
+		}
+
+		for _, expr := range []string{`[a-z]+`, `(?m)^bar`, `(\Abar)|x`, `\Abaz|bar`, `^[|]|bar`} {
+			re := regexp.MustCompile(expr)
+			if got, err := r.ReadRegexpMatch(re); got != nil || !errors.Is(err, ErrUnanchored) {
+				t.Errorf("ReadRegexpMatch(%v) = %q, %v; want nil, %v", re, got, err, ErrUnanchored)
+			}
+		}
+		if r.Len() != 4 {
+			t.Errorf("Len after unanchored ReadRegexpMatch = %d; want 4", r.Len())
+		}
+	})
+}
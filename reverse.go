@@ -0,0 +1,111 @@
+package reader
+
+import (
+	"io"
+	"strings"
+)
+
+// A ReverseReader implements the io.Reader, io.ByteReader and io.Seeker
+// interfaces by reading from a byte slice or a string from back to
+// front. Offsets and lengths refer to the reversed stream, so position 0
+// is the end of the data.
+// The zero value for ReverseReader operates like a ReverseReader of an
+// empty slice or an empty string.
+type ReverseReader[S ~[]byte | ~string] struct {
+	s   S
+	off int64 // bytes read from the end; the unread data is s[:len(s)-off]
+}
+
+// NewReverse returns a new ReverseReader reading from s.
+func NewReverse[S ~[]byte | ~string](s S) *ReverseReader[S] { return &ReverseReader[S]{s: s} }
+
+// Len returns the number of bytes of the unread portion of the
+// slice or string.
+func (r *ReverseReader[S]) Len() int {
+	if r.off >= int64(len(r.s)) {
+		return 0
+	}
+	return int(int64(len(r.s)) - r.off)
+}
+
+// Size returns the original length of the underlying byte slice or string.
+func (r *ReverseReader[S]) Size() int64 { return int64(len(r.s)) }
+
+// unread returns the unread portion of the slice or string, which is
+// read from its end.
+func (r *ReverseReader[S]) unread() S { return r.s[:r.Len()] }
+
+// Read implements the io.Reader interface, filling p with the bytes
+// preceding the current position, last byte first.
+func (r *ReverseReader[S]) Read(p []byte) (n int, err error) {
+	s := r.unread()
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+	n = min(len(p), len(s))
+	for i := range n {
+		p[i] = s[len(s)-1-i]
+	}
+	r.off += int64(n)
+	return n, nil
+}
+
+// ReadByte implements the io.ByteReader interface.
+func (r *ReverseReader[S]) ReadByte() (byte, error) {
+	s := r.unread()
+	if len(s) == 0 {
+		return 0, io.EOF
+	}
+	r.off++
+	return s[len(s)-1], nil
+}
+
+// Seek implements the io.Seeker interface. Offsets are positions in the
+// reversed stream: io.SeekStart is relative to the end of the data and
+// io.SeekEnd to its beginning.
+func (r *ReverseReader[S]) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	default:
+		return 0, &ReaderError{Method: "ReverseReader.Seek", Offset: r.off, Cause: ErrInvalidWhence}
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += int64(len(r.s))
+	}
+
+	if offset < 0 {
+		return 0, &ReaderError{Method: "ReverseReader.Seek", Offset: offset, Cause: ErrNegativePosition}
+	}
+
+	r.off = offset
+	return offset, nil
+}
+
+// ReadLineBack returns the line preceding the current position and
+// moves back to its start, so that successive calls yield the lines of
+// the data from last to first. Lines are terminated by "\n" or "\r\n",
+// which is not included in the line; as with Reader.Lines, a terminator
+// at the end of the data does not start an empty line.
+// The returned line is a view into the underlying data, not a copy.
+// If no bytes remain, ReadLineBack returns io.EOF.
+func (r *ReverseReader[S]) ReadLineBack() (S, error) {
+	s := r.unread()
+	if len(s) == 0 {
+		return s, io.EOF
+	}
+
+	end := len(s)
+	if s[end-1] == '\n' {
+		end--
+		if end > 0 && s[end-1] == '\r' {
+			end--
+		}
+	}
+	start := strings.LastIndexByte(asString(s[:end]), '\n') + 1
+	r.off = int64(len(r.s) - start)
+	return s[start:end], nil
+}
+
+// Reset resets the ReverseReader to be reading from the end of s.
+func (r *ReverseReader[S]) Reset(s S) { *r = ReverseReader[S]{s: s} }
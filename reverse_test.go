@@ -0,0 +1,119 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReverseReader(t *testing.T) {
+	t.Parallel()
+
+	testReverseReader[[]byte](t)
+	testReverseReader[string](t)
+}
+
+func testReverseReader[S ~[]byte | ~string](t *testing.T) {
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		t.Parallel()
+
+		const s = "héllo, 世界\r\nline two\n"
+		r := NewReverse(S(s))
+		if r.Len() != len(s) || r.Size() != int64(len(s)) {
+			t.Errorf("Len, Size = %d, %d; want %d, %d", r.Len(), r.Size(), len(s), len(s))
+		}
+
+		// Reading back to front and reversing again gives the original.
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		slices.Reverse(b)
+		if string(b) != s {
+			t.Errorf("reversed ReadAll = %q; want %q", b, s)
+		}
+		if r.Len() != 0 || r.Size() != int64(len(s)) {
+			t.Errorf("Len, Size after ReadAll = %d, %d; want 0, %d", r.Len(), r.Size(), len(s))
+		}
+
+		if pos, err := r.Seek(1, io.SeekStart); pos != 1 || err != nil {
+			t.Fatalf("Seek(1, SeekStart) = %d, %v; want 1, nil", pos, err)
+		}
+		if c, err := r.ReadByte(); c != 'o' || err != nil {
+			t.Errorf("ReadByte = %q, %v; want 'o', nil", c, err)
+		}
+		if pos, err := r.Seek(-3, io.SeekEnd); pos != int64(len(s))-3 || err != nil {
+			t.Fatalf("Seek(-3, SeekEnd) = %d, %v; want %d, nil", pos, err, len(s)-3)
+		}
+		b = make([]byte, 4)
+		// The bytes of "hé", reversed byte by byte.
+		if n, err := r.Read(b); n != 3 || string(b[:n]) != "\xa9\xc3h" || err != nil {
+			t.Errorf("Read = %q, %v; want %q, nil", b[:n], err, "\xa9\xc3h")
+		}
+		if _, err := r.Seek(-1, io.SeekCurrent); err != nil {
+			t.Fatal(err)
+		}
+		if c, err := r.ReadByte(); c != 'h' || err != nil {
+			t.Errorf("ReadByte after Seek(-1, SeekCurrent) = %q, %v; want 'h', nil", c, err)
+		}
+		if _, err := r.ReadByte(); err != io.EOF {
+			t.Errorf("ReadByte at EOF error = %v; want EOF", err)
+		}
+		if _, err := r.Seek(-100, io.SeekCurrent); !errors.Is(err, ErrNegativePosition) {
+			t.Errorf("Seek(-100, SeekCurrent) error = %v; want one wrapping %v", err, ErrNegativePosition)
+		}
+	})
+}
+
+func TestReverseReaderReadLineBack(t *testing.T) {
+	t.Parallel()
+
+	testReverseReaderReadLineBack[[]byte](t)
+	testReverseReaderReadLineBack[string](t)
+}
+
+func testReverseReaderReadLineBack[S ~[]byte | ~string](t *testing.T) {
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			s    string
+			want []string
+		}{
+			{"", nil},
+			{"\n", []string{""}},
+			{"one", []string{"one"}},
+			{"one\ntwo\n", []string{"two", "one"}},
+			{"one\r\ntwo\r\n", []string{"two", "one"}},
+			{"one\n\ntwo", []string{"two", "", "one"}},
+			{"\none\r\n\r\n", []string{"", "one", ""}},
+			{"a\rb\n", []string{"a\rb"}},
+		}
+
+		for _, tt := range tests {
+			data := S(tt.s)
+			r := NewReverse(data)
+			var got []string
+			for {
+				line, err := r.ReadLineBack()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadLineBack(%q) error = %v", tt.s, err)
+				}
+				if _, _, ok := Overlap(New(data), New(line)); len(line) > 0 && !ok {
+					t.Errorf("ReadLineBack(%q) = %q is not a view into the data", tt.s, line)
+				}
+				got = append(got, string(line))
+			}
+			if !slices.Equal(tt.want, got) {
+				t.Errorf("ReadLineBack(%q) lines = %q; want %q", tt.s, got, tt.want)
+			}
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package reader
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Scanner reads the tokens of a Reader's unread data as delimited by a
+// bufio.SplitFunc, with the API of bufio.Scanner. Unlike bufio.Scanner,
+// it does not copy the tokens into a buffer and does not limit their size,
+// since all of the data is in memory.
+type Scanner[S ~[]byte | ~string] struct {
+	r       *Reader[S]
+	split   bufio.SplitFunc // nil for the built-in line and word splitting
+	words   bool            // with split nil, whether to split into words
+	token   S
+	err     error
+	empties int
+	done    bool
+}
+
+// maxConsecutiveEmptyTokens is the number of empty tokens a split function
+// may return without advancing before Scanner.Scan panics, as
+// bufio.Scanner does.
+const maxConsecutiveEmptyTokens = 100
+
+// Scanner returns a Scanner reading from r with the given split function.
+// If split is nil, the Scanner splits the data into lines, as
+// bufio.Scanner does by default, like LineScanner.
+// Since all of the data is in memory, split is always called with atEOF
+// set to true.
+// The Reader is advanced past each token as Scan returns it.
+func (r *Reader[S]) Scanner(split bufio.SplitFunc) *Scanner[S] {
+	return &Scanner[S]{r: r, split: split}
+}
+
+// LineScanner returns a Scanner splitting the data into lines with the
+// same results as bufio.ScanLines, but without calling a split function.
+func (r *Reader[S]) LineScanner() *Scanner[S] {
+	return &Scanner[S]{r: r}
+}
+
+// WordScanner returns a Scanner splitting the data into words with the
+// same results as bufio.ScanWords, but without calling a split function.
+func (r *Reader[S]) WordScanner() *Scanner[S] {
+	return &Scanner[S]{r: r, words: true}
+}
+
+// Scan advances the Scanner to the next token, which will then be
+// available through the Token method. It returns false when the scan
+// stops, either by reaching the end of the data or an error; after Scan
+// returns false, the Err method returns any error that occurred during
+// scanning, except that if it was io.EOF or bufio.ErrFinalToken, Err
+// returns nil. On an error, the Reader is left positioned at the start
+// of the failed token.
+// Scan panics if the split function returns too many empty tokens
+// without advancing the input, as bufio.Scanner does.
+func (sc *Scanner[S]) Scan() bool {
+	if sc.done {
+		return false
+	}
+	r := sc.r
+	r.lastRead = opInvalid
+	switch {
+	case sc.split != nil:
+		return sc.scanSplit()
+	case sc.words:
+		return sc.scanWords()
+	}
+
+	// Lines, as with bufio.ScanLines.
+	if r.off >= r.end() {
+		return sc.stop(nil)
+	}
+	line := r.unread()
+	if i := strings.IndexByte(asString(line), '\n'); i >= 0 {
+		line = line[:i]
+		r.off++
+	}
+	r.off += int64(len(line))
+	if n := len(line); n > 0 && line[n-1] == '\r' {
+		line = line[:n-1]
+	}
+	sc.token = line
+	return true
+}
+
+// scanWords finds the next word, as with bufio.ScanWords.
+func (sc *Scanner[S]) scanWords() bool {
+	r := sc.r
+	s := asString(r.unread())
+	start := 0
+	for start < len(s) {
+		ch, size := utf8.DecodeRuneInString(s[start:])
+		if !unicode.IsSpace(ch) {
+			break
+		}
+		start += size
+	}
+	if start == len(s) {
+		r.off += int64(start)
+		return sc.stop(nil)
+	}
+	end := start
+	for end < len(s) {
+		ch, size := utf8.DecodeRuneInString(s[end:])
+		if unicode.IsSpace(ch) {
+			break
+		}
+		end += size
+	}
+	sc.token = r.s[r.off+int64(start) : r.off+int64(end)]
+	// The space ending the word is consumed with it.
+	if end < len(s) {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+	}
+	r.off += int64(end)
+	return true
+}
+
+// scanSplit finds the next token by calling the split function.
+func (sc *Scanner[S]) scanSplit() bool {
+	r := sc.r
+	for {
+		rest := r.unread()
+		data := asBytes(rest)
+		advance, token, err := sc.split(data, true)
+		if err != nil && err != bufio.ErrFinalToken {
+			return sc.stop(err)
+		}
+		switch {
+		case advance < 0:
+			return sc.stop(bufio.ErrNegativeAdvance)
+		case advance > len(data):
+			return sc.stop(bufio.ErrAdvanceTooFar)
+		}
+		r.off += int64(advance)
+
+		if token == nil {
+			if err != nil || advance == 0 {
+				return sc.stop(nil)
+			}
+			continue
+		}
+		if advance > 0 {
+			sc.empties = 0
+		} else if sc.empties++; sc.empties > maxConsecutiveEmptyTokens {
+			panic("reader.Scanner.Scan: too many empty tokens without progressing")
+		}
+
+		if i, ok := offsetIn(data, token); ok {
+			sc.token = rest[i : i+len(token)]
+		} else {
+			sc.token = S(token)
+		}
+		if err != nil {
+			// bufio.ErrFinalToken: this token is the last one.
+			sc.done = true
+		}
+		return true
+	}
+}
+
+// stop ends the scan with the given error. io.EOF from the split
+// function ends the scan without an error, as in bufio.Scanner.
+func (sc *Scanner[S]) stop(err error) bool {
+	if err == io.EOF {
+		err = nil
+	}
+	sc.token = sc.token[:0]
+	sc.err = err
+	sc.done = true
+	return false
+}
+
+// Token returns the most recent token generated by a call to Scan.
+// Tokens that the split function returns as sub-slices of its input, and
+// those of LineScanner and WordScanner, are views into the underlying
+// data; other tokens are converted to S.
+func (sc *Scanner[S]) Token() S { return sc.token }
+
+// Err returns the first non-EOF error that was encountered by the Scanner.
+func (sc *Scanner[S]) Err() error { return sc.err }
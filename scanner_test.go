@@ -0,0 +1,228 @@
+package reader_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+// scanCommas splits on commas and ends the scan at a ';' with a final
+// token, exercising bufio.ErrFinalToken.
+func scanCommas(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, ",;"); i >= 0 {
+		if data[i] == ';' {
+			return i + 1, data[:i], bufio.ErrFinalToken
+		}
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+var errBadToken = errors.New("bad token")
+
+// scanFailing returns bytes one at a time until it meets an 'x'.
+func scanFailing(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+	if data[0] == 'x' {
+		return 0, nil, errBadToken
+	}
+	return 1, data[:1], nil
+}
+
+var scannerTests = []string{
+	"",
+	"one\ntwo\r\n\nthree",
+	"  héllo wörld   trailing  \n",
+	"a,b,,c;d,e",
+	"ab\xffcx,y",
+	strings.Repeat("long", bufio.MaxScanTokenSize/2),
+}
+
+var scannerSplits = []struct {
+	name  string
+	split bufio.SplitFunc
+}{
+	{"ScanLines", bufio.ScanLines},
+	{"ScanWords", bufio.ScanWords},
+	{"ScanRunes", bufio.ScanRunes},
+	{"ScanBytes", bufio.ScanBytes},
+	{"scanCommas", scanCommas},
+	{"scanFailing", scanFailing},
+}
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	testScanner[[]byte](t)
+	testScanner[string](t)
+}
+
+func testScanner[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, s := range scannerTests {
+			for _, tt := range scannerSplits {
+				var want []string
+				bs := bufio.NewScanner(strings.NewReader(s))
+				bs.Buffer(nil, len(s)+1)
+				bs.Split(tt.split)
+				for bs.Scan() {
+					want = append(want, bs.Text())
+				}
+
+				var got []string
+				sc := New(S(s)).Scanner(tt.split)
+				for sc.Scan() {
+					got = append(got, string(sc.Token()))
+				}
+				if fmt.Sprint(got) != fmt.Sprint(want) || sc.Err() != bs.Err() {
+					t.Errorf("Scanner(%s) on %.20q = %q, %v; bufio.Scanner gives %q, %v", tt.name, s, got, sc.Err(), want, bs.Err())
+				}
+				if sc.Scan() {
+					t.Errorf("Scanner(%s) on %.20q: Scan after the end returned true", tt.name, s)
+				}
+			}
+
+			for _, tt := range []struct {
+				name  string
+				split bufio.SplitFunc
+				sc    *Scanner[S]
+			}{
+				{"LineScanner", bufio.ScanLines, New(S(s)).LineScanner()},
+				{"WordScanner", bufio.ScanWords, New(S(s)).WordScanner()},
+			} {
+				var want, got []string
+				for tok := range New(S(s)).TokensSeq(tt.split) {
+					want = append(want, string(tok))
+				}
+				for tt.sc.Scan() {
+					got = append(got, string(tt.sc.Token()))
+				}
+				if fmt.Sprint(got) != fmt.Sprint(want) || tt.sc.Err() != nil {
+					t.Errorf("%s on %.20q = %q, %v; want %q, nil", tt.name, s, got, tt.sc.Err(), want)
+				}
+			}
+		}
+	})
+}
+
+func TestScannerAllocs(t *testing.T) {
+	testScannerAllocs[[]byte](t)
+	testScannerAllocs[string](t)
+}
+
+func testScannerAllocs[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("one,two,three,four,five,six,seven,eight"))
+		var sc *Scanner[S]
+		n := testing.AllocsPerRun(10, func() {
+			_, _ = r.Seek(0, io.SeekStart)
+			sc = r.Scanner(scanCommas)
+			for sc.Scan() {
+			}
+		})
+		// The Scanner itself may be allocated, but not the tokens.
+		if n > 1 {
+			t.Errorf("Scanner(scanCommas) over 8 tokens: %v allocs; want at most 1", n)
+		}
+	})
+}
+
+func TestScannerDefault(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("one\r\ntwo\nthree")
+	r := New(data)
+	sc := r.Scanner(nil)
+	var toks [][]byte
+	for sc.Scan() {
+		toks = append(toks, sc.Token())
+	}
+	if len(toks) != 3 || string(toks[2]) != "three" || sc.Err() != nil {
+		t.Fatalf("Scanner(nil) = %q, %v; want three lines", toks, sc.Err())
+	}
+	if &toks[1][0] != &data[5] {
+		t.Error("Scanner(nil): tokens are not views into the data")
+	}
+	if r.Len() != 0 {
+		t.Errorf("Scanner(nil): Len = %d; want 0", r.Len())
+	}
+}
+
+func TestScannerError(t *testing.T) {
+	t.Parallel()
+
+	r := New("abxcd")
+	sc := r.Scanner(scanFailing)
+	for sc.Scan() {
+	}
+	if sc.Err() != errBadToken {
+		t.Errorf("Err = %v; want %v", sc.Err(), errBadToken)
+	}
+	if r.Len() != 3 {
+		t.Errorf("Len = %d; want 3, positioned at the failed token", r.Len())
+	}
+
+	sc = New("abc").Scanner(func([]byte, bool) (int, []byte, error) { return 4, nil, nil })
+	if sc.Scan() || sc.Err() != bufio.ErrAdvanceTooFar {
+		t.Errorf("Scan with advance too far: Err = %v; want %v", sc.Err(), bufio.ErrAdvanceTooFar)
+	}
+}
+
+func TestScannerSplitEOF(t *testing.T) {
+	t.Parallel()
+
+	// A split function may end the scan by returning io.EOF, which Err
+	// does not report.
+	r := New("ab,cd")
+	sc := r.Scanner(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, ','); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		return 0, nil, io.EOF
+	})
+	var got []string
+	for sc.Scan() {
+		got = append(got, string(sc.Token()))
+	}
+	if len(got) != 1 || got[0] != "ab" || sc.Err() != nil {
+		t.Errorf("Scan with io.EOF from split = %q, %v; want [ab], nil", got, sc.Err())
+	}
+	if r.Len() != 2 {
+		t.Errorf("Len = %d; want 2", r.Len())
+	}
+}
+
+func BenchmarkReaderScanner(b *testing.B) {
+	data := bytes.Repeat([]byte("a line of moderate length\n"), 1000)
+	for _, tt := range []struct {
+		name  string
+		split bufio.SplitFunc
+	}{
+		{"builtin", nil},
+		{"split", bufio.ScanLines},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				sc := New(data).Scanner(tt.split)
+				for sc.Scan() {
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+)
+
+var errScanStateRead = errors.New("ScanState's Read should not be called; use ReadRune")
+
+// ScanState returns a fmt.ScanState reading from r, so that a value
+// implementing fmt.Scanner can scan itself directly from the unread data
+// without the buffering of fmt.Fscan:
+//
+//	err := v.Scan(r.ScanState(0, false), 'v')
+//
+// If widthOK is true, the state reports width as its Width and returns
+// io.EOF from ReadRune once width runes have been read, as fmt does for a
+// verb with a width such as %5v.
+// Token returns a view into the underlying data, which must not be
+// modified, rather than a copy.
+func (r *Reader[S]) ScanState(width int, widthOK bool) fmt.ScanState {
+	return &scanState[S]{r: r, width: width, widthOK: widthOK}
+}
+
+// scanState implements fmt.ScanState on top of a Reader.
+type scanState[S ~[]byte | ~string] struct {
+	r       *Reader[S]
+	width   int
+	widthOK bool
+	count   int // runes read, limited by width if widthOK
+}
+
+// ReadRune reads the next rune, returning io.EOF at the end of the data
+// or once the width has been reached.
+func (s *scanState[S]) ReadRune() (ch rune, size int, err error) {
+	if s.widthOK && s.count >= s.width {
+		return 0, 0, io.EOF
+	}
+	ch, size, err = s.r.ReadRune()
+	if err == nil {
+		s.count++
+	}
+	return ch, size, err
+}
+
+// UnreadRune unreads the rune returned by the last call to ReadRune.
+func (s *scanState[S]) UnreadRune() error {
+	if err := s.r.UnreadRune(); err != nil {
+		return err
+	}
+	s.count--
+	return nil
+}
+
+// SkipSpace skips white space, including newlines, as fmt.Sscan does.
+func (s *scanState[S]) SkipSpace() {
+	for {
+		ch, _, err := s.ReadRune()
+		if err != nil {
+			return
+		}
+		if !unicode.IsSpace(ch) {
+			_ = s.UnreadRune()
+			return
+		}
+	}
+}
+
+// Token skips white space if skipSpace is true and then returns the run
+// of runes satisfying f, or !unicode.IsSpace if f is nil.
+// In strict UTF-8 mode an invalid sequence ends the token and its
+// *InvalidUTF8Error is returned with the runes read so far.
+func (s *scanState[S]) Token(skipSpace bool, f func(rune) bool) (token []byte, err error) {
+	if skipSpace {
+		s.SkipSpace()
+	}
+	if f == nil {
+		f = func(ch rune) bool { return !unicode.IsSpace(ch) }
+	}
+	start := s.r.off
+	for {
+		var ch rune
+		ch, _, err = s.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			break
+		}
+		if !f(ch) {
+			_ = s.UnreadRune()
+			break
+		}
+	}
+	return asBytes(s.r.s[start:s.r.off]), err
+}
+
+// Width returns the width given to ScanState and whether it was set.
+func (s *scanState[S]) Width() (wid int, ok bool) {
+	return s.width, s.widthOK
+}
+
+// Read always returns an error: fmt.ScanState requires that scanners use
+// ReadRune instead.
+func (s *scanState[S]) Read(buf []byte) (n int, err error) {
+	return 0, &ReaderError{Method: "ScanState.Read", Offset: s.r.off, Cause: errScanStateRead}
+}
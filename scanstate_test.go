@@ -0,0 +1,90 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+// ipv4 is a fmt.Scanner used to check that ScanState behaves like the
+// state fmt.Sscan passes to scanners.
+type ipv4 [4]byte
+
+func (ip *ipv4) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := state.Token(true, func(ch rune) bool { return '0' <= ch && ch <= '9' || ch == '.' })
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(string(tok), ".")
+	if len(parts) != 4 {
+		return errors.New("ipv4: want four octets")
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 8)
+		if err != nil {
+			return err
+		}
+		ip[i] = byte(n)
+	}
+	return nil
+}
+
+func TestReaderScanState(t *testing.T) {
+	t.Parallel()
+
+	testReaderScanState[[]byte](t)
+	testReaderScanState[string](t)
+}
+
+func testReaderScanState[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, s := range []string{"10.0.0.1", " \n\t192.168.1.254 rest", "1.2.3", "300.1.1.1", ""} {
+			var want ipv4
+			_, wantErr := fmt.Sscan(s, &want)
+			r := New(S(s))
+			var got ipv4
+			err := got.Scan(r.ScanState(0, false), 'v')
+			if got != want || (err == nil) != (wantErr == nil) {
+				t.Errorf("Scan(%q) = %v, %v; Sscan gives %v, %v", s, got, err, want, wantErr)
+			}
+		}
+	})
+}
+
+func TestReaderScanStateWidth(t *testing.T) {
+	t.Parallel()
+
+	testReaderScanStateWidth[[]byte](t)
+	testReaderScanStateWidth[string](t)
+}
+
+func testReaderScanStateWidth[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("  héllo world"))
+		state := r.ScanState(3, true)
+		if wid, ok := state.Width(); wid != 3 || !ok {
+			t.Errorf("Width = %d, %t; want 3, true", wid, ok)
+		}
+		// The skipped spaces count toward the width, as in fmt.
+		tok, err := state.Token(true, nil)
+		if string(tok) != "h" || err != nil {
+			t.Errorf("Token = %q, %v; want \"h\", nil", tok, err)
+		}
+
+		tok, _ = r.ScanState(0, false).Token(false, nil)
+		if string(tok) != "éllo" {
+			t.Errorf("Token = %q; want \"éllo\"", tok)
+		}
+		if _, err := r.ScanState(0, false).Read(make([]byte, 1)); err == nil {
+			t.Error("Read succeeded; want an error")
+		}
+	})
+}
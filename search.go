@@ -0,0 +1,115 @@
+package reader
+
+import (
+	"io"
+	"strings"
+)
+
+// A Searcher finds instances of a fixed pattern using the
+// Boyer-Moore-Horspool algorithm. Building a Searcher precomputes a
+// skip table, so reusing one amortises that cost over repeated searches
+// for the same pattern in large inputs.
+// A Searcher is safe for concurrent use.
+type Searcher struct {
+	pattern string
+	skip    [256]int // distance to shift for each byte in the text
+}
+
+// NewSearcher returns a Searcher for pattern.
+func NewSearcher(pattern []byte) *Searcher {
+	s := &Searcher{pattern: string(pattern)}
+	last := len(pattern) - 1
+	for i := range s.skip {
+		s.skip[i] = len(pattern)
+	}
+	for i := 0; i < last; i++ {
+		s.skip[pattern[i]] = last - i
+	}
+	return s
+}
+
+// Index returns the index of the first instance of the pattern in text,
+// or -1 if it is not present.
+func (s *Searcher) Index(text []byte) int { return s.index(asString(text)) }
+
+func (s *Searcher) index(text string) int {
+	m := len(s.pattern)
+	if m == 0 {
+		return 0
+	}
+
+	last := m - 1
+	for i := 0; i+m <= len(text); i += s.skip[text[i+last]] {
+		if text[i+last] == s.pattern[last] && text[i:i+last] == s.pattern[:last] {
+			return i
+		}
+	}
+	return -1
+}
+
+// Search returns the offset, relative to the current position, of the
+// first instance of pattern in the unread data, and whether it was found.
+// Search does not modify the Reader and does not allocate; it does not
+// precompute a skip table, so to search for the same pattern repeatedly,
+// build a Searcher once with NewSearcher and use SearchWith.
+func (r *Reader[S]) Search(pattern []byte) (int64, bool) {
+	i := strings.Index(asString(r.unread()), asString(pattern))
+	return int64(i), i >= 0
+}
+
+// SearchWith is like Search but uses the precomputed Searcher s.
+func (r *Reader[S]) SearchWith(s *Searcher) (int64, bool) {
+	i := s.index(asString(r.unread()))
+	return int64(i), i >= 0
+}
+
+// LastIndex returns the offset, relative to the current position, of the
+// last instance of sep in the unread data, or -1 if sep is not present,
+// as for finding the last '/' of a path. LastIndex does not modify the
+// Reader.
+func (r *Reader[S]) LastIndex(sep []byte) int64 {
+	return int64(strings.LastIndex(asString(r.unread()), asString(sep)))
+}
+
+// LastIndexByte returns the offset, relative to the current position, of
+// the last instance of c in the unread data, or -1 if c is not present.
+// LastIndexByte does not modify the Reader.
+func (r *Reader[S]) LastIndexByte(c byte) int64 {
+	return int64(strings.LastIndexByte(asString(r.unread()), c))
+}
+
+// SkipTo advances the Reader to the first instance of pattern in the
+// unread data, such as the next synchronisation marker after a parse
+// error, and returns the number of bytes skipped and true. If pattern is
+// not present, SkipTo advances to the end of the data and returns the
+// number of bytes skipped and false.
+func (r *Reader[S]) SkipTo(pattern []byte) (int64, bool) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	i := strings.Index(s, asString(pattern))
+	if i < 0 {
+		r.off += int64(len(s))
+		return int64(len(s)), false
+	}
+	r.off += int64(i)
+	return int64(i), true
+}
+
+// ReadUntilAny reads up to the first instance of any of the characters
+// in chars, such as "/?#" when splitting a URL, and returns the data
+// before it, leaving the Reader positioned at the delimiter. As with
+// strings.IndexAny, non-ASCII characters in chars match their UTF-8
+// encodings. The returned data is a view into the underlying data, not a
+// copy. If none of the characters is present, ReadUntilAny returns the
+// rest of the data and io.EOF.
+func (r *Reader[S]) ReadUntilAny(chars string) (S, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	i := strings.IndexAny(asString(s), chars)
+	if i < 0 {
+		r.off += int64(len(s))
+		return s, io.EOF
+	}
+	r.off += int64(i)
+	return s[:i], nil
+}
@@ -0,0 +1,211 @@
+package reader_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+var searchTests = []struct {
+	text, pattern string
+	want          int
+}{
+	{"", "", 0},
+	{"abc", "", 0},
+	{"", "a", -1},
+	{"abc", "abcd", -1},
+	{"abc", "abc", 0},
+	{"abc", "c", 2},
+	{"xabcabd", "abd", 4},
+	{"aaaaaaab", "aab", 5},
+	{"the quick brown fox", "fox", 16},
+	{"the quick brown fox", "cat", -1},
+	{"GCATCGCAGAGAGTATACAGTACG", "GCAGAGAG", 5},
+}
+
+func TestSearcher(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range searchTests {
+		if got := NewSearcher([]byte(tt.pattern)).Index([]byte(tt.text)); tt.want != got {
+			t.Errorf("Searcher(%q).Index(%q) = %d; want %d", tt.pattern, tt.text, got, tt.want)
+		}
+	}
+
+	// Compare against bytes.Index over every pattern of the test data.
+	for i := 0; i < 50; i++ {
+		pattern := testBytes[i*7 : i*7+i%9+1]
+		if got, want := NewSearcher(pattern).Index(testBytes), bytes.Index(testBytes, pattern); want != got {
+			t.Errorf("Searcher(%q).Index = %d; want %d", pattern, got, want)
+		}
+	}
+}
+
+func TestReaderSearch(t *testing.T) {
+	t.Parallel()
+
+	testReaderSearch[[]byte](t)
+	testReaderSearch[string](t)
+}
+
+func testReaderSearch[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("one two one two"))
+		if off, ok := r.Search([]byte("two")); off != 4 || !ok {
+			t.Errorf("Search = %d, %t; want 4, true", off, ok)
+		}
+
+		if _, err := r.Seek(5, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		s := NewSearcher([]byte("two"))
+		if off, ok := r.SearchWith(s); off != 7 || !ok {
+			t.Errorf("SearchWith = %d, %t; want 7, true", off, ok)
+		}
+		if off, ok := r.Search([]byte("three")); off != -1 || ok {
+			t.Errorf("Search(three) = %d, %t; want -1, false", off, ok)
+		}
+		if r.Len() != 10 {
+			t.Errorf("Search modified the reader: Len = %d; want 10", r.Len())
+		}
+	})
+}
+
+func TestReaderSearchAllocs(t *testing.T) {
+	r := New("one two one two")
+	p := []byte("two")
+	if n := testing.AllocsPerRun(100, func() {
+		_, _ = r.Search(p)
+	}); n != 0 {
+		t.Errorf("Search allocs = %v; want 0", n)
+	}
+}
+
+func BenchmarkSearcher(b *testing.B) {
+	text := []byte(strings.Repeat("lorem ipsum dolor sit amet ", 10000) + "NEEDLE")
+	s := NewSearcher([]byte("NEEDLE"))
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		s.Index(text)
+	}
+}
+
+func TestReaderSkipTo(t *testing.T) {
+	t.Parallel()
+
+	testReaderSkipTo[[]byte](t)
+	testReaderSkipTo[string](t)
+}
+
+func testReaderSkipTo[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("garbage\xffSYNCframe1SYNCframe2"))
+		if n, ok := r.SkipTo([]byte("SYNC")); n != 8 || !ok {
+			t.Errorf("SkipTo = %d, %t; want 8, true", n, ok)
+		}
+		// Already at the pattern: nothing is skipped.
+		if n, ok := r.SkipTo([]byte("SYNC")); n != 0 || !ok {
+			t.Errorf("second SkipTo = %d, %t; want 0, true", n, ok)
+		}
+		_, _ = r.Seek(1, io.SeekCurrent)
+		if n, ok := r.SkipTo([]byte("SYNC")); n != 9 || !ok {
+			t.Errorf("third SkipTo = %d, %t; want 9, true", n, ok)
+		}
+		_, _ = r.Seek(1, io.SeekCurrent)
+		if n, ok := r.SkipTo([]byte("SYNC")); n != 9 || ok {
+			t.Errorf("SkipTo missing pattern = %d, %t; want 9, false", n, ok)
+		}
+		if r.Len() != 0 {
+			t.Errorf("after SkipTo missing pattern: Len = %d; want 0", r.Len())
+		}
+		if n, ok := r.SkipTo([]byte("SYNC")); n != 0 || ok {
+			t.Errorf("at EOF: SkipTo = %d, %t; want 0, false", n, ok)
+		}
+	})
+}
+
+func TestReaderLastIndex(t *testing.T) {
+	t.Parallel()
+
+	testReaderLastIndex[[]byte](t)
+	testReaderLastIndex[string](t)
+}
+
+func testReaderLastIndex[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("/usr/local/bin/go"))
+		_, _ = r.Seek(4, io.SeekStart)
+		tests := []struct {
+			sep  string
+			want int64
+		}{
+			{"/", 10},
+			{"l", 5},
+			{"/bin", 6},
+			{"", 13},
+			{"/usr", -1},
+			{"x", -1},
+		}
+		for _, tt := range tests {
+			if got := r.LastIndex([]byte(tt.sep)); got != tt.want {
+				t.Errorf("LastIndex(%q) = %d; want %d", tt.sep, got, tt.want)
+			}
+			if len(tt.sep) == 1 {
+				if got := r.LastIndexByte(tt.sep[0]); got != tt.want {
+					t.Errorf("LastIndexByte(%q) = %d; want %d", tt.sep[0], got, tt.want)
+				}
+			}
+		}
+		if r.Len() != 13 {
+			t.Errorf("LastIndex modified the Reader: Len = %d; want 13", r.Len())
+		}
+	})
+}
+
+func TestReaderReadUntilAny(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadUntilAny[[]byte](t)
+	testReaderReadUntilAny[string](t)
+}
+
+func testReaderReadUntilAny[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("/päth/to?q=1#frag"))
+		want := []struct {
+			s   string
+			err error
+		}{
+			{"", nil}, {"päth", nil}, {"to", nil}, {"q=1", nil}, {"frag", io.EOF}, {"", io.EOF},
+		}
+		for i, w := range want {
+			got, err := r.ReadUntilAny("/?#")
+			if string(got) != w.s || err != w.err {
+				t.Errorf("ReadUntilAny #%d = %q, %v; want %q, %v", i, got, err, w.s, w.err)
+			}
+			if err == nil {
+				// Skip the delimiter, which is not consumed.
+				if c, _ := r.ReadByte(); !strings.ContainsRune("/?#", rune(c)) {
+					t.Errorf("ReadUntilAny #%d left the Reader at %q; want a delimiter", i, c)
+				}
+			}
+		}
+
+		r = New(S("a,b"))
+		if got, err := r.ReadUntilAny(""); string(got) != "a,b" || err != io.EOF {
+			t.Errorf(`ReadUntilAny("") = %q, %v; want "a,b", EOF`, got, err)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package reader
+
+import "sync/atomic"
+
+// ReaderStats holds aggregate counters describing how a Reader has been used.
+// The counters cover only the methods of the io interfaces the Reader
+// implements and their close relatives, as listed for each counter; data
+// consumed by the other methods, such as the Read*, Parse*, Skip* and
+// iterator methods, is not counted.
+type ReaderStats struct {
+	BytesRead    int64 // bytes returned by Read, ReadAt, ReadAtFull, ReadByte, ReadRune, ReadLastRune, WriteTo and Hash
+	SeekCount    int64 // calls to Seek and Replay
+	ReadCount    int64 // calls to Read
+	ReadAtCount  int64 // calls to ReadAt and ReadAtFull
+	RunesDecoded int64 // runes returned by ReadRune and ReadLastRune
+}
+
+// readerStats holds the live counters of a Reader with statistics enabled.
+// The methods are no-ops on a nil *readerStats, so that a Reader without
+// statistics pays only for a nil check.
+type readerStats struct {
+	bytesRead    atomic.Int64
+	seekCount    atomic.Int64
+	readCount    atomic.Int64
+	readAtCount  atomic.Int64
+	runesDecoded atomic.Int64
+}
+
+func (s *readerStats) read(n int) {
+	if s != nil {
+		s.readCount.Add(1)
+		s.bytesRead.Add(int64(n))
+	}
+}
+
+func (s *readerStats) readAt(n int) {
+	if s != nil {
+		s.readAtCount.Add(1)
+		s.bytesRead.Add(int64(n))
+	}
+}
+
+func (s *readerStats) readRune(size int) {
+	if s != nil {
+		s.runesDecoded.Add(1)
+		s.bytesRead.Add(int64(size))
+	}
+}
+
+func (s *readerStats) bytes(n int64) {
+	if s != nil {
+		s.bytesRead.Add(n)
+	}
+}
+
+func (s *readerStats) seek() {
+	if s != nil {
+		s.seekCount.Add(1)
+	}
+}
+
+// EnableStats turns on collection of the counters reported by Stats.
+// Collection is off by default to avoid its overhead.
+// EnableStats must not be called concurrently with other methods.
+// The counters survive Reset.
+func (r *Reader[S]) EnableStats() {
+	if opts := r.options(); opts.stats == nil {
+		opts.stats = new(readerStats)
+	}
+}
+
+// stats returns the live counters of the Reader, or nil if statistics
+// are not enabled.
+func (r *Reader[S]) stats() *readerStats {
+	if r.opts == nil {
+		return nil
+	}
+	return r.opts.stats
+}
+
+// Stats returns a snapshot of the Reader's usage counters.
+// It returns the zero ReaderStats if EnableStats has not been called.
+func (r *Reader[S]) Stats() ReaderStats {
+	s := r.stats()
+	if s == nil {
+		return ReaderStats{}
+	}
+	return ReaderStats{
+		BytesRead:    s.bytesRead.Load(),
+		SeekCount:    s.seekCount.Load(),
+		ReadCount:    s.readCount.Load(),
+		ReadAtCount:  s.readAtCount.Load(),
+		RunesDecoded: s.runesDecoded.Load(),
+	}
+}
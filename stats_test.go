@@ -0,0 +1,93 @@
+package reader_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderStats(t *testing.T) {
+	t.Parallel()
+
+	testReaderStats[[]byte](t)
+	testReaderStats[string](t)
+}
+
+func testReaderStats[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("héllo, world"))
+		if s := r.Stats(); s != (ReaderStats{}) {
+			t.Errorf("disabled: Stats = %+v; want zero", s)
+		}
+		_, _ = r.Read(make([]byte, 2))
+		if s := r.Stats(); s != (ReaderStats{}) {
+			t.Errorf("disabled after Read: Stats = %+v; want zero", s)
+		}
+
+		r.EnableStats()
+		_, _ = r.Seek(0, io.SeekStart)
+		if n, err := r.Read(make([]byte, 4)); n != 4 || err != nil {
+			t.Fatalf("Read = %d, %v", n, err)
+		}
+		want := ReaderStats{BytesRead: 4, SeekCount: 1, ReadCount: 1}
+		if s := r.Stats(); want != s {
+			t.Errorf("after Read: Stats = %+v; want %+v", s, want)
+		}
+
+		_, _ = r.ReadAt(make([]byte, 3), 0)
+		want.ReadAtCount++
+		want.BytesRead += 3
+		if s := r.Stats(); want != s {
+			t.Errorf("after ReadAt: Stats = %+v; want %+v", s, want)
+		}
+
+		_, _, _ = r.ReadRune()
+		_, _ = r.ReadByte()
+		want.RunesDecoded++
+		want.BytesRead += 2
+		if s := r.Stats(); want != s {
+			t.Errorf("after ReadRune, ReadByte: Stats = %+v; want %+v", s, want)
+		}
+
+		_, _ = r.WriteTo(&bytes.Buffer{})
+		want.BytesRead += 7
+		if s := r.Stats(); want != s {
+			t.Errorf("after WriteTo: Stats = %+v; want %+v", s, want)
+		}
+	})
+}
+
+func TestReaderStatsConcurrentReadAt(t *testing.T) {
+	t.Parallel()
+
+	testReaderStatsConcurrentReadAt[[]byte](t)
+	testReaderStatsConcurrentReadAt[string](t)
+}
+
+func testReaderStatsConcurrentReadAt[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("0123456789"))
+		r.EnableStats()
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var buf [1]byte
+				_, _ = r.ReadAt(buf[:], int64(i))
+			}(i)
+		}
+		wg.Wait()
+		if s := r.Stats(); s.ReadAtCount != 5 || s.BytesRead != 5 {
+			t.Errorf("Stats = %+v; want ReadAtCount 5, BytesRead 5", s)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package reader
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// asciiSpace reports whether a byte is ASCII white space.
+var asciiSpace = [256]bool{'\t': true, '\n': true, '\v': true, '\f': true, '\r': true, ' ': true}
+
+// httpTokenChar reports whether a byte is an RFC 7230 tchar, a character
+// allowed in an HTTP token.
+var httpTokenChar = [256]bool{
+	'!': true, '#': true, '$': true, '%': true, '&': true, '\'': true, '*': true,
+	'+': true, '-': true, '.': true, '^': true, '_': true, '`': true, '|': true, '~': true,
+	'0': true, '1': true, '2': true, '3': true, '4': true, '5': true, '6': true, '7': true, '8': true, '9': true,
+	'A': true, 'B': true, 'C': true, 'D': true, 'E': true, 'F': true, 'G': true, 'H': true, 'I': true,
+	'J': true, 'K': true, 'L': true, 'M': true, 'N': true, 'O': true, 'P': true, 'Q': true, 'R': true,
+	'S': true, 'T': true, 'U': true, 'V': true, 'W': true, 'X': true, 'Y': true, 'Z': true,
+	'a': true, 'b': true, 'c': true, 'd': true, 'e': true, 'f': true, 'g': true, 'h': true, 'i': true,
+	'j': true, 'k': true, 'l': true, 'm': true, 'n': true, 'o': true, 'p': true, 'q': true, 'r': true,
+	's': true, 't': true, 'u': true, 'v': true, 'w': true, 'x': true, 'y': true, 'z': true,
+}
+
+// SkipWhitespace advances the Reader past any ASCII white space
+// ('\t', '\n', '\v', '\f', '\r' and ' ') and returns the number of bytes skipped.
+func (r *Reader[S]) SkipWhitespace() int {
+	r.lastRead = opInvalid
+	start := r.off
+	for r.off < r.end() && asciiSpace[r.s[r.off]] {
+		r.off++
+	}
+	return int(r.off - start)
+}
+
+// CountLines returns the number of lines in the unread data, as
+// yielded by Lines: lines are terminated by "\n" or "\r\n", and a final
+// line without a terminator counts as a line too, so that "a\nb" has two
+// lines, "a\n" one and "" none. It counts only up to the end of the data
+// the Reader is bounded to, as by SubReader.
+// CountLines does not modify the Reader.
+func (r *Reader[S]) CountLines() int64 {
+	s := asString(r.unread())
+	n := int64(strings.Count(s, "\n"))
+	if len(s) > 0 && s[len(s)-1] != '\n' {
+		n++
+	}
+	return n
+}
+
+// Count returns the number of non-overlapping instances of sep in the
+// unread data. As with strings.Count, if sep is empty, Count returns 1
+// plus the number of UTF-8-encoded code points in the unread data.
+// Count does not modify the Reader.
+func (r *Reader[S]) Count(sep S) int64 {
+	return int64(strings.Count(asString(r.unread()), asString(sep)))
+}
+
+// CountByte returns the number of instances of c in the unread data.
+// CountByte does not modify the Reader.
+func (r *Reader[S]) CountByte(c byte) int64 {
+	return int64(bytes.Count(asBytes(r.unread()), []byte{c}))
+}
+
+// NthLineOffset returns the offset from the start of the underlying data
+// at which its nth line, counting from 1, begins. Lines are terminated
+// by '\n'; a final line need not be. It returns an error if n is not
+// positive or if the data has fewer than n lines.
+// NthLineOffset does not modify the Reader; seeking to the returned
+// offset implements "go to line n".
+func (r *Reader[S]) NthLineOffset(n int) (int64, error) {
+	if n < 1 {
+		return 0, &ReaderError{Method: "NthLineOffset", Offset: r.off, Cause: ErrLineOutOfRange}
+	}
+	s := asString(r.s)
+	off := 0
+	for ; n > 1 && off < len(s); n-- {
+		i := strings.IndexByte(s[off:], '\n')
+		if i < 0 {
+			break
+		}
+		off += i + 1
+	}
+	if n > 1 || off >= len(s) {
+		return 0, &ReaderError{Method: "NthLineOffset", Offset: r.off, Cause: ErrLineOutOfRange}
+	}
+	return int64(off), nil
+}
+
+// ReadHTTPToken reads an HTTP token as defined by RFC 7230, such as a
+// method or header field name: a run of the characters
+// !#$%&'*+-.^_`|~, ASCII digits and ASCII letters. It stops at the first
+// other byte, which is not consumed.
+// If no token character is present at the current position, ReadHTTPToken
+// returns an error wrapping ErrSyntax and does not advance. If no data
+// remains, it returns io.EOF.
+func (r *Reader[S]) ReadHTTPToken() (string, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if len(s) == 0 {
+		return "", io.EOF
+	}
+	i := 0
+	for i < len(s) && httpTokenChar[s[i]] {
+		i++
+	}
+	if i == 0 {
+		return "", &ReaderError{
+			Method: "ReadHTTPToken",
+			Offset: r.off,
+			Cause:  fmt.Errorf("%w: invalid token character %q", ErrSyntax, s[0]),
+		}
+	}
+	r.off += int64(i)
+	return string(s[:i]), nil
+}
+
+// trimASCIISpace returns s without leading and trailing ASCII white space.
+func trimASCIISpace[S ~[]byte | ~string](s S) S {
+	i, j := 0, len(s)
+	for i < j && asciiSpace[s[i]] {
+		i++
+	}
+	for j > i && asciiSpace[s[j-1]] {
+		j--
+	}
+	return s[i:j]
+}
+
+// ReadKeyValue reads a line terminated by lineSep, or by the end of the
+// data, and splits it at the first keySep into a key and a value, each
+// with leading and trailing ASCII white space removed, as for INI files
+// and HTTP headers. If the line has no keySep, the whole line is the key
+// and the value is empty. The Reader is advanced past the line and its
+// terminator.
+// If no data remains, ReadKeyValue returns "", "", io.EOF.
+func (r *Reader[S]) ReadKeyValue(keySep, lineSep byte) (key, value string, err error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if len(s) == 0 {
+		return "", "", io.EOF
+	}
+
+	line := s
+	if i := strings.IndexByte(asString(s), lineSep); i >= 0 {
+		line = s[:i]
+		r.off++
+	}
+	r.off += int64(len(line))
+	k, v := line, line[len(line):]
+	if i := strings.IndexByte(asString(line), keySep); i >= 0 {
+		k, v = line[:i], line[i+1:]
+	}
+	return string(trimASCIISpace(k)), string(trimASCIISpace(v)), nil
+}
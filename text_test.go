@@ -0,0 +1,273 @@
+package reader_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderSkipWhitespace(t *testing.T) {
+	t.Parallel()
+
+	testReaderSkipWhitespace[[]byte](t)
+	testReaderSkipWhitespace[string](t)
+}
+
+func testReaderSkipWhitespace[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(" \t\r\n\v\fabc "))
+		if n := r.SkipWhitespace(); n != 6 {
+			t.Errorf("SkipWhitespace = %d; want 6", n)
+		}
+		if n := r.SkipWhitespace(); n != 0 {
+			t.Errorf("second SkipWhitespace = %d; want 0", n)
+		}
+		if c, _ := r.ReadByte(); c != 'a' {
+			t.Errorf("ReadByte = %q; want 'a'", c)
+		}
+	})
+}
+
+func TestReaderCountLines(t *testing.T) {
+	t.Parallel()
+
+	testReaderCountLines[[]byte](t)
+	testReaderCountLines[string](t)
+}
+
+func testReaderCountLines[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("one\ntwo\r\n\nfour"))
+		if n := r.CountLines(); n != 4 {
+			t.Errorf("CountLines = %d; want 4", n)
+		}
+		_, _ = r.Seek(4, io.SeekStart)
+		if n := r.CountLines(); n != 3 {
+			t.Errorf("after Seek: CountLines = %d; want 3", n)
+		}
+		if r.Len() != len("two\r\n\nfour") {
+			t.Errorf("CountLines modified the Reader: Len = %d", r.Len())
+		}
+	})
+}
+
+func TestReaderCount(t *testing.T) {
+	t.Parallel()
+
+	testReaderCount[[]byte](t)
+	testReaderCount[string](t)
+}
+
+func testReaderCount[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("skip|aaaa|\xff\x00é\x00"))
+		_, _ = r.Seek(5, io.SeekStart)
+		tests := []struct {
+			sep  string
+			want int64
+		}{
+			{"a", 4},
+			{"aa", 2},
+			{"aaa", 1},
+			{"|", 1},
+			{"skip", 0},
+			{"", 10}, // 9 runes plus 1, as with strings.Count
+		}
+		for _, tt := range tests {
+			if got := r.Count(S(tt.sep)); got != tt.want {
+				t.Errorf("Count(%q) = %d; want %d", tt.sep, got, tt.want)
+			}
+		}
+		for _, tt := range []struct {
+			c    byte
+			want int64
+		}{{'a', 4}, {0, 2}, {0xff, 1}, {0xc3, 1}, {'s', 0}} {
+			if got := r.CountByte(tt.c); got != tt.want {
+				t.Errorf("CountByte(%q) = %d; want %d", tt.c, got, tt.want)
+			}
+		}
+		if r.Len() != 10 {
+			t.Errorf("Count modified the Reader: Len = %d; want 10", r.Len())
+		}
+	})
+}
+
+func TestReaderCountByteAllocs(t *testing.T) {
+	r := New("binary\x00data\x00")
+	if n := testing.AllocsPerRun(100, func() {
+		_ = r.CountByte(0)
+	}); n != 0 {
+		t.Errorf("CountByte allocs = %v; want 0", n)
+	}
+}
+
+func TestReaderNthLineOffset(t *testing.T) {
+	t.Parallel()
+
+	testReaderNthLineOffset[[]byte](t)
+	testReaderNthLineOffset[string](t)
+}
+
+func testReaderNthLineOffset[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const s = "one\ntwo\r\n\nfour\n"
+	tests := []struct {
+		n       int
+		want    int64
+		wanterr string
+	}{
+		{1, 0, ""},
+		{2, 4, ""},
+		{3, 9, ""},
+		{4, 10, ""},
+		{5, 0, "reader.Reader.NthLineOffset: line number out of range at offset 6"},
+		{0, 0, "reader.Reader.NthLineOffset: line number out of range at offset 6"},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(s))
+			_, _ = r.Seek(6, io.SeekStart) // the position does not matter
+			got, err := r.NthLineOffset(tt.n)
+			if tt.wanterr != "" {
+				if err == nil || tt.wanterr != err.Error() {
+					t.Errorf("NthLineOffset(%d) error = %v; want %s", tt.n, err, tt.wanterr)
+				}
+				continue
+			}
+			if tt.want != got || err != nil {
+				t.Errorf("NthLineOffset(%d) = %d, %v; want %d, nil", tt.n, got, err, tt.want)
+			}
+		}
+
+		// Go to line 3 and read it.
+		r := New(S("a\nb\nc"))
+		off, err := r.NthLineOffset(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = r.Seek(off, io.SeekStart)
+		if c, _ := r.ReadByte(); c != 'c' {
+			t.Errorf("line 3 starts with %q; want 'c'", c)
+		}
+
+		r = New(S(""))
+		if _, err := r.NthLineOffset(1); err == nil {
+			t.Errorf("empty: NthLineOffset(1) error = nil; want out of range")
+		}
+	})
+}
+
+func TestReaderCountLinesMatchesLines(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "\n", "a", "a\n", "a\r\nb", "a\n\n", "\r\n\r\nx\r"} {
+		r := New(s)
+		want := int64(0)
+		for range r.Lines() {
+			want++
+		}
+		r.Reset(s)
+		if n := r.CountLines(); n != want {
+			t.Errorf("CountLines(%q) = %d; Lines yields %d", s, n, want)
+		}
+	}
+}
+
+func BenchmarkReaderCountLines(b *testing.B) {
+	data := bytes.Repeat([]byte("a line of moderate length for counting\n"), 1<<16)
+	r := New(data)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if r.CountLines() != 1<<16 {
+			b.Fatal("wrong count")
+		}
+	}
+}
+
+func TestReaderReadHTTPToken(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadHTTPToken[[]byte](t)
+	testReaderReadHTTPToken[string](t)
+}
+
+func testReaderReadHTTPToken[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("GET /x HTTP/1.1\r\nX-Custom_Header!#$%&'*+.^`|~09: v"))
+		if tok, err := r.ReadHTTPToken(); tok != "GET" || err != nil {
+			t.Errorf("ReadHTTPToken = %q, %v; want GET, nil", tok, err)
+		}
+		if tok, err := r.ReadHTTPToken(); tok != "" || !errors.Is(err, ErrSyntax) {
+			t.Errorf("ReadHTTPToken at space = %q, %v; want \"\", ErrSyntax", tok, err)
+		}
+		if r.Len() != len(" /x HTTP/1.1\r\nX-Custom_Header!#$%&'*+.^`|~09: v") {
+			t.Errorf("failed ReadHTTPToken advanced the Reader: Len = %d", r.Len())
+		}
+
+		_, _ = r.Seek(17, io.SeekStart)
+		if tok, err := r.ReadHTTPToken(); tok != "X-Custom_Header!#$%&'*+.^`|~09" || err != nil {
+			t.Errorf("ReadHTTPToken = %q, %v; want header name, nil", tok, err)
+		}
+		if c, _ := r.ReadByte(); c != ':' {
+			t.Errorf("ReadByte after ReadHTTPToken = %q; want ':'", c)
+		}
+
+		_, _ = r.Seek(0, io.SeekEnd)
+		if tok, err := r.ReadHTTPToken(); tok != "" || err != io.EOF {
+			t.Errorf("ReadHTTPToken at EOF = %q, %v; want \"\", EOF", tok, err)
+		}
+	})
+}
+
+func TestReaderReadKeyValue(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadKeyValue[[]byte](t)
+	testReaderReadKeyValue[string](t)
+}
+
+func testReaderReadKeyValue[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("name = gopher\r\n  path=/a=b \n\nflag\n empty =\nlast: one"))
+		want := [][2]string{
+			{"name", "gopher"},
+			{"path", "/a=b"},
+			{"", ""},
+			{"flag", ""},
+			{"empty", ""},
+			{"last: one", ""},
+		}
+		for i, w := range want {
+			key, value, err := r.ReadKeyValue('=', '\n')
+			if key != w[0] || value != w[1] || err != nil {
+				t.Errorf("ReadKeyValue #%d = %q, %q, %v; want %q, %q, nil", i, key, value, err, w[0], w[1])
+			}
+		}
+		if key, value, err := r.ReadKeyValue('=', '\n'); key != "" || value != "" || err != io.EOF {
+			t.Errorf("ReadKeyValue at EOF = %q, %q, %v; want \"\", \"\", EOF", key, value, err)
+		}
+
+		r = New(S("Host: example.com;Accept:*/*"))
+		for _, w := range [][2]string{{"Host", "example.com"}, {"Accept", "*/*"}} {
+			if key, value, err := r.ReadKeyValue(':', ';'); key != w[0] || value != w[1] || err != nil {
+				t.Errorf("ReadKeyValue(':', ';') = %q, %q, %v; want %q, %q, nil", key, value, err, w[0], w[1])
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package reader
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// A TLV is a tag-length-value record as read by ReadTLV.
+type TLV[S ~[]byte | ~string] struct {
+	Tag   uint64
+	Value S
+}
+
+// ReadTLV reads a tag-length-value record made of an unsigned tag of
+// tagWidth bytes and an unsigned length of lenWidth bytes, both in the
+// given byte order, followed by that many bytes of value, which are
+// returned as a view into the underlying data. The widths must each be
+// 1, 2, 4 or 8; if both are 1, order is unused and may be nil.
+// If no data remains, ReadTLV returns io.EOF. If the data ends before the
+// record does, it returns io.ErrUnexpectedEOF and does not advance.
+func (r *Reader[S]) ReadTLV(tagWidth, lenWidth int, order binary.ByteOrder) (tag uint64, value S, err error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	if !validUintWidth(tagWidth) || !validUintWidth(lenWidth) {
+		return 0, s[:0], &ReaderError{Method: "ReadTLV", Offset: r.off, Cause: fmt.Errorf("%w %d/%d", ErrInvalidWidth, tagWidth, lenWidth)}
+	}
+	if len(s) == 0 {
+		return 0, s, io.EOF
+	}
+	hdr := tagWidth + lenWidth
+	if len(s) < hdr {
+		return 0, s[:0], io.ErrUnexpectedEOF
+	}
+
+	b := asBytes(s[:hdr])
+	tag = decodeUint(b[:tagWidth], order)
+	n := decodeUint(b[tagWidth:], order)
+	if n > uint64(len(s)-hdr) {
+		return 0, s[:0], io.ErrUnexpectedEOF
+	}
+	r.off += int64(hdr) + int64(n)
+	return tag, s[hdr : hdr+int(n)], nil
+}
+
+// TLVs returns an iterator over the tag-length-value records of the
+// unread data, as read by ReadTLV with the same arguments.
+// The iteration ends at the end of the data or after yielding the first
+// error, with a zero TLV; for a truncated record the error wraps
+// io.ErrUnexpectedEOF and holds the offset of the record, at which the
+// Reader is left positioned.
+// The Reader is advanced past each record before it is yielded.
+func (r *Reader[S]) TLVs(tagWidth, lenWidth int, order binary.ByteOrder) iter.Seq2[TLV[S], error] {
+	return func(yield func(TLV[S], error) bool) {
+		for {
+			off := r.off
+			tag, value, err := r.ReadTLV(tagWidth, lenWidth, order)
+			if err == io.EOF {
+				return
+			}
+			if err == io.ErrUnexpectedEOF {
+				err = &ReaderError{Method: "TLVs", Offset: off, Cause: fmt.Errorf("truncated record: %w", err)}
+			}
+			if err != nil {
+				yield(TLV[S]{}, err)
+				return
+			}
+			if !yield(TLV[S]{tag, value}, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package reader_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderReadTLV(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadTLV[[]byte](t)
+	testReaderReadTLV[string](t)
+}
+
+func testReaderReadTLV[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		// 1-byte tags and 2-byte big-endian lengths.
+		r := New(S("\x01\x00\x03abc\x02\x00\x00\x03\x00\x01z"))
+		for _, want := range []TLV[string]{{1, "abc"}, {2, ""}, {3, "z"}} {
+			tag, value, err := r.ReadTLV(1, 2, binary.BigEndian)
+			if want.Tag != tag || want.Value != string(value) || err != nil {
+				t.Errorf("ReadTLV = %d, %q, %v; want %d, %q, nil", tag, value, err, want.Tag, want.Value)
+			}
+		}
+		if _, _, err := r.ReadTLV(1, 2, binary.BigEndian); err != io.EOF {
+			t.Errorf("at EOF: ReadTLV error = %v; want EOF", err)
+		}
+
+		// 4-byte little-endian tags and 8-byte lengths.
+		var buf []byte
+		buf = binary.LittleEndian.AppendUint32(buf, 0xdeadbeef)
+		buf = binary.LittleEndian.AppendUint64(buf, 2)
+		buf = append(buf, "ok"...)
+		r = New(S(buf))
+		if tag, value, err := r.ReadTLV(4, 8, binary.LittleEndian); tag != 0xdeadbeef || string(value) != "ok" || err != nil {
+			t.Errorf("ReadTLV(4, 8) = %#x, %q, %v; want 0xdeadbeef, %q, nil", tag, value, err, "ok")
+		}
+
+		for _, s := range []string{"\x01", "\x01\x00", "\x01\x00\x04abc", "\x01\xff\xff"} {
+			r = New(S(s))
+			if _, _, err := r.ReadTLV(1, 2, binary.BigEndian); err != io.ErrUnexpectedEOF {
+				t.Errorf("ReadTLV(%q) error = %v; want unexpected EOF", s, err)
+			}
+			if r.Len() != len(s) {
+				t.Errorf("ReadTLV(%q): Len = %d; want %d", s, r.Len(), len(s))
+			}
+		}
+
+		r = New(S("\x01\x01a"))
+		if _, _, err := r.ReadTLV(3, 1, nil); err == nil {
+			t.Errorf("ReadTLV(3, 1) error = nil; want invalid width error")
+		}
+		if tag, value, err := r.ReadTLV(1, 1, nil); tag != 1 || string(value) != "a" || err != nil {
+			t.Errorf("ReadTLV(1, 1, nil) = %d, %q, %v; want 1, %q, nil", tag, value, err, "a")
+		}
+	})
+}
+
+func TestReaderTLVs(t *testing.T) {
+	t.Parallel()
+
+	r := New([]byte("\x01\x02ab\x02\x00\x03\x05xyz"))
+	var got []string
+	var err error
+	for rec, e := range r.TLVs(1, 1, nil) {
+		if e != nil {
+			err = e
+			break
+		}
+		got = append(got, fmt.Sprintf("%d:%s", rec.Tag, rec.Value))
+	}
+	if want := []string{"1:ab", "2:"}; fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Errorf("TLVs = %q; want %q", got, want)
+	}
+	if want := "reader.Reader.TLVs: truncated record: unexpected EOF at offset 6"; err == nil || err.Error() != want {
+		t.Errorf("TLVs error = %v; want %s", err, want)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("TLVs error does not wrap io.ErrUnexpectedEOF")
+	}
+	if r.Len() != 5 {
+		t.Errorf("after TLVs: Len = %d; want 5", r.Len())
+	}
+
+	sr := New("\x01\x01a\x02\x01b\x03\x01c")
+	n := 0
+	for rec, err := range sr.TLVs(1, 1, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; rec.Tag == 2 {
+			break
+		}
+	}
+	if n != 2 || sr.Len() != 3 {
+		t.Errorf("after break: records, Len = %d, %d; want 2, 3", n, sr.Len())
+	}
+}
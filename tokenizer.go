@@ -0,0 +1,134 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Tokenizer splits the unread data of a Reader into tokens separated
+// by delimiters, honoring quotes and escapes as a shell does, so that
+// `a "b c" d` yields the three tokens a, b c and d.
+//
+// The fields may be changed before the first call to Next.
+type Tokenizer[S ~[]byte | ~string] struct {
+	// Delims holds the bytes that separate tokens. A run of delimiters
+	// counts as one, and leading delimiters are skipped.
+	Delims string
+
+	// Quotes holds the bytes that open a quoted section, which extends
+	// to the next occurrence of the same byte and may contain delimiters
+	// and the other quote bytes. A quoted section may make up all or
+	// part of a token; the quotes themselves are removed.
+	Quotes string
+
+	// Escape is the byte that makes the byte following it literal,
+	// within quoted sections too, or 0 for none. It is removed.
+	Escape byte
+
+	r *Reader[S]
+}
+
+// NewTokenizer returns a Tokenizer reading from r that splits at ASCII
+// white space, with the double and single quotes as quotes and the
+// backslash as escape.
+func NewTokenizer[S ~[]byte | ~string](r *Reader[S]) *Tokenizer[S] {
+	return &Tokenizer[S]{Delims: " \t\r\n", Quotes: `"'`, Escape: '\\', r: r}
+}
+
+// Next returns the next token and advances the Reader past it and the
+// delimiter that follows it. If no token remains, Next returns io.EOF.
+// A token without escapes that is either unquoted or quoted as a whole
+// is returned as a view into the underlying data; only a token that must
+// be rewritten to remove quotes or escapes is copied.
+// A quoted section missing its closing quote, or an escape at the end of
+// the data, yields an error wrapping io.ErrUnexpectedEOF whose offset is
+// that of the opening quote or of the escape; on error the Reader is not
+// advanced.
+func (t *Tokenizer[S]) Next() (S, error) {
+	r := t.r
+	r.lastRead = opInvalid
+	rest := r.unread()
+	s := asString(rest)
+
+	start := 0
+	for start < len(s) && strings.IndexByte(t.Delims, s[start]) >= 0 {
+		start++
+	}
+	if start == len(s) {
+		r.off += int64(start)
+		return rest[len(rest):], io.EOF
+	}
+
+	special := t.Delims + t.Quotes
+	if t.Escape != 0 {
+		special += string(t.Escape)
+	}
+	j := strings.IndexAny(s[start:], special)
+	switch {
+	case j < 0:
+		// An unquoted token at the end of the data.
+		r.off += int64(len(s))
+		return rest[start:], nil
+	case strings.IndexByte(t.Delims, s[start+j]) >= 0:
+		// An unquoted token.
+		r.off += int64(start + j + 1)
+		return rest[start : start+j], nil
+	case j == 0 && s[start] != t.Escape:
+		// A token that may be quoted as a whole.
+		stop := string(s[start])
+		if t.Escape != 0 {
+			stop += string(t.Escape)
+		}
+		k := strings.IndexAny(s[start+1:], stop)
+		if k >= 0 && s[start+1+k] == s[start] {
+			end := start + 1 + k + 1
+			if end == len(s) || strings.IndexByte(t.Delims, s[end]) >= 0 {
+				r.off += int64(min(end+1, len(s)))
+				return rest[start+1 : end-1], nil
+			}
+		}
+	}
+
+	var b []byte
+	var quote byte
+	qoff := 0
+	i := start
+loop:
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == t.Escape && t.Escape != 0:
+			if i+1 == len(s) {
+				return rest[:0], &ReaderError{
+					Method: "Tokenizer.Next",
+					Offset: r.off + int64(i),
+					Cause:  fmt.Errorf("escape at end of data: %w", io.ErrUnexpectedEOF),
+				}
+			}
+			i++
+			b = append(b, s[i])
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				b = append(b, c)
+			}
+		case strings.IndexByte(t.Quotes, c) >= 0:
+			quote, qoff = c, i
+		case strings.IndexByte(t.Delims, c) >= 0:
+			break loop
+		default:
+			b = append(b, c)
+		}
+	}
+	if quote != 0 {
+		return rest[:0], &ReaderError{
+			Method: "Tokenizer.Next",
+			Offset: r.off + int64(qoff),
+			Cause:  fmt.Errorf("unterminated quote %q: %w", quote, io.ErrUnexpectedEOF),
+		}
+	}
+	r.off += int64(min(i+1, len(s)))
+	return S(b), nil
+}
@@ -0,0 +1,111 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"unsafe"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+var tokenizerTests = []struct {
+	s    string
+	want []string
+}{
+	{"", nil},
+	{"  \t\n", nil},
+	{`a "b c" d`, []string{"a", "b c", "d"}},
+	{`  cp  'my file.txt'   dest/ `, []string{"cp", "my file.txt", "dest/"}},
+	{`""`, []string{""}},
+	{`a "" b`, []string{"a", "", "b"}},
+	{`pre"quoted part"post x`, []string{"prequoted partpost", "x"}},
+	{`"it's" 'say "hi"'`, []string{"it's", `say "hi"`}},
+	{`a\ b c\"d`, []string{"a b", `c"d`}},
+	{`"a \" b"`, []string{`a " b`}},
+	{`"ab"cd`, []string{"abcd"}},
+}
+
+func TestTokenizer(t *testing.T) {
+	t.Parallel()
+
+	testTokenizer[[]byte](t)
+	testTokenizer[string](t)
+}
+
+func testTokenizer[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tokenizerTests {
+			r := New(S(tt.s))
+			tok := NewTokenizer(r)
+			var got []string
+			for {
+				s, err := tok.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next(%q): %v", tt.s, err)
+				}
+				got = append(got, string(s))
+			}
+			if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.want) {
+				t.Errorf("tokens of %q = %q; want %q", tt.s, got, tt.want)
+			}
+			if r.Len() != 0 {
+				t.Errorf("tokens of %q: Len = %d; want 0", tt.s, r.Len())
+			}
+		}
+
+		// Configured delimiters, quotes and escape.
+		tok := NewTokenizer(New(S(`a,|b,c|,d^,e`)))
+		tok.Delims, tok.Quotes, tok.Escape = ",", "|", '^'
+		for _, want := range []string{"a", "b,c", "d,e"} {
+			if got, err := tok.Next(); string(got) != want || err != nil {
+				t.Errorf("Next = %q, %v; want %q, nil", got, err, want)
+			}
+		}
+
+		for _, tt := range []struct {
+			s   string
+			off int64
+		}{
+			{`ab "cd`, 3},
+			{`ab 'c"d`, 3},
+			{`ab cd\`, 5},
+		} {
+			r := New(S(tt.s))
+			tok := NewTokenizer(r)
+			_, _ = tok.Next()
+			got, err := tok.Next()
+			var rerr *ReaderError
+			if len(got) != 0 || !errors.Is(err, io.ErrUnexpectedEOF) || !errors.As(err, &rerr) || rerr.Offset != tt.off {
+				t.Errorf("Next(%q) = %q, %v; want error at offset %d", tt.s, got, err, tt.off)
+			}
+			if r.Len() != len(tt.s)-3 {
+				t.Errorf("Next(%q) advanced on error: Len = %d", tt.s, r.Len())
+			}
+		}
+	})
+}
+
+func TestTokenizerZeroCopy(t *testing.T) {
+	t.Parallel()
+
+	data := `plain "quoted whole" "re"written`
+	tok := NewTokenizer(New(data))
+	for _, want := range []bool{true, true, false} {
+		s, err := tok.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := uintptr(unsafe.Pointer(unsafe.StringData(s)))
+		base := uintptr(unsafe.Pointer(unsafe.StringData(data)))
+		if got := base <= p && p < base+uintptr(len(data)); got != want {
+			t.Errorf("token %q is a view into the data: %t; want %t", s, got, want)
+		}
+	}
+}
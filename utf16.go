@@ -0,0 +1,87 @@
+package reader
+
+import (
+	"encoding/binary"
+	"io"
+	"unicode"
+	"unicode/utf16"
+)
+
+// ReadRuneUTF16 reads a single UTF-16 encoded Unicode character in the
+// given byte order and returns the rune and its size in bytes, 2 or 4
+// for a surrogate pair. An unpaired surrogate is returned as
+// unicode.ReplacementChar with size 2, so that decoding resumes with the
+// next code unit.
+// If no bytes remain, ReadRuneUTF16 returns io.EOF; if a single byte
+// remains, it returns io.ErrUnexpectedEOF and does not advance.
+func (r *Reader[S]) ReadRuneUTF16(order binary.ByteOrder) (ch rune, size int, err error) {
+	r.lastRead = opInvalid
+	s := asBytes(r.unread())
+	switch len(s) {
+	case 0:
+		return 0, 0, io.EOF
+	case 1:
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+
+	r1 := rune(order.Uint16(s))
+	switch {
+	case !utf16.IsSurrogate(r1):
+		ch, size = r1, 2
+	case len(s) >= 4:
+		ch, size = utf16.DecodeRune(r1, rune(order.Uint16(s[2:]))), 4
+		if ch == unicode.ReplacementChar {
+			// Not a valid pair; only the first code unit is consumed.
+			size = 2
+		}
+	default:
+		ch, size = unicode.ReplacementChar, 2
+	}
+	r.off += int64(size)
+	r.lastRead = opReadUTF16Rune2
+	if size == 4 {
+		r.lastRead = opReadUTF16Rune4
+	}
+	return ch, size, nil
+}
+
+// UnreadRuneUTF16 unreads the character returned by the last call to
+// ReadRuneUTF16. If the most recent method called on the Reader was not
+// a successful ReadRuneUTF16, UnreadRuneUTF16 returns an error.
+func (r *Reader[S]) UnreadRuneUTF16() error {
+	switch r.lastRead {
+	default:
+		return &ReaderError{Method: "UnreadRuneUTF16", Offset: r.off, Cause: ErrUnreadRune}
+	case opReadUTF16Rune2:
+		r.off -= 2
+	case opReadUTF16Rune4:
+		r.off -= 4
+	}
+	r.lastRead = opInvalid
+	return nil
+}
+
+// ReadBOM checks whether the unread data starts with a UTF-16 byte order
+// mark, U+FEFF, and if so advances past it and returns the byte order it
+// indicates, binary.LittleEndian for 0xFF 0xFE and binary.BigEndian for
+// 0xFE 0xFF, and true. Otherwise the Reader is not advanced and ReadBOM
+// returns false.
+// If no bytes remain, ReadBOM returns io.EOF; a single remaining byte
+// cannot hold a BOM, so ReadBOM then returns false.
+func (r *Reader[S]) ReadBOM() (binary.ByteOrder, bool, error) {
+	r.lastRead = opInvalid
+	s := r.unread()
+	switch {
+	case len(s) == 0:
+		return nil, false, io.EOF
+	case len(s) == 1:
+		return nil, false, nil
+	case s[0] == 0xff && s[1] == 0xfe:
+		r.off += 2
+		return binary.LittleEndian, true, nil
+	case s[0] == 0xfe && s[1] == 0xff:
+		r.off += 2
+		return binary.BigEndian, true, nil
+	}
+	return nil, false, nil
+}
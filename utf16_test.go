@@ -0,0 +1,193 @@
+package reader_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderReadRuneUTF16(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadRuneUTF16[[]byte](t)
+	testReaderReadRuneUTF16[string](t)
+}
+
+func testReaderReadRuneUTF16[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const s = "aé世\U0001f600￿"
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, order := range []binary.AppendByteOrder{binary.BigEndian, binary.LittleEndian} {
+			var buf []byte
+			for _, u := range utf16.Encode([]rune(s)) {
+				buf = order.AppendUint16(buf, u)
+			}
+
+			r := New(S(string(buf)))
+			for _, want := range s {
+				wantSize := 2 * len(utf16.AppendRune(nil, want))
+				ch, size, err := r.ReadRuneUTF16(order.(binary.ByteOrder))
+				if want != ch || wantSize != size || err != nil {
+					t.Errorf("%v: ReadRuneUTF16 = %U, %d, %v; want %U, %d, nil", order, ch, size, err, want, wantSize)
+				}
+			}
+			if _, _, err := r.ReadRuneUTF16(order.(binary.ByteOrder)); err != io.EOF {
+				t.Errorf("%v: at EOF: ReadRuneUTF16 error = %v; want EOF", order, err)
+			}
+		}
+	})
+}
+
+func TestReaderReadRuneUTF16Invalid(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadRuneUTF16Invalid[[]byte](t)
+	testReaderReadRuneUTF16Invalid[string](t)
+}
+
+func testReaderReadRuneUTF16Invalid[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name  string
+		s     string
+		want  []rune
+		sizes []int
+	}{
+		{"lone high surrogate", "\xd8\x3d\x00\x61", []rune{utf8.RuneError, 'a'}, []int{2, 2}},
+		{"lone low surrogate", "\xde\x00\x00\x61", []rune{utf8.RuneError, 'a'}, []int{2, 2}},
+		{"two high surrogates", "\xd8\x3d\xd8\x3d\xde\x00", []rune{utf8.RuneError, '\U0001f600'}, []int{2, 4}},
+		{"high surrogate at end", "\x00\x61\xd8\x3d", []rune{'a', utf8.RuneError}, []int{2, 2}},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			for i, want := range tt.want {
+				ch, size, err := r.ReadRuneUTF16(binary.BigEndian)
+				if want != ch || tt.sizes[i] != size || err != nil {
+					t.Errorf("%s: ReadRuneUTF16 #%d = %U, %d, %v; want %U, %d, nil", tt.name, i, ch, size, err, want, tt.sizes[i])
+				}
+			}
+		}
+
+		r := New(S("\x00a\x00"))
+		_, _, _ = r.ReadRuneUTF16(binary.BigEndian)
+		if _, _, err := r.ReadRuneUTF16(binary.BigEndian); err != io.ErrUnexpectedEOF {
+			t.Errorf("odd byte: ReadRuneUTF16 error = %v; want unexpected EOF", err)
+		}
+		if r.Len() != 1 {
+			t.Errorf("odd byte: Len = %d; want 1", r.Len())
+		}
+	})
+}
+
+func TestReaderUnreadRuneUTF16(t *testing.T) {
+	t.Parallel()
+
+	testReaderUnreadRuneUTF16[[]byte](t)
+	testReaderUnreadRuneUTF16[string](t)
+}
+
+func testReaderUnreadRuneUTF16[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	// 'a', U+1F600 as a surrogate pair, then a lone high surrogate.
+	const s = "\x00\x61\xd8\x3d\xde\x00\xd8\x3d"
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(s))
+		if err := r.UnreadRuneUTF16(); !errors.Is(err, ErrUnreadRune) {
+			t.Errorf("UnreadRuneUTF16 at beginning = %v; want %v", err, ErrUnreadRune)
+		}
+
+		for _, want := range []rune{'a', '\U0001f600', unicode.ReplacementChar} {
+			ch, size, err := r.ReadRuneUTF16(binary.BigEndian)
+			if want != ch || err != nil {
+				t.Fatalf("ReadRuneUTF16 = %U, %v; want %U, nil", ch, err, want)
+			}
+			n := r.Len()
+			if err := r.UnreadRuneUTF16(); err != nil {
+				t.Fatalf("UnreadRuneUTF16 after %U: %v", want, err)
+			}
+			if r.Len() != n+size {
+				t.Errorf("Len after UnreadRuneUTF16 = %d; want %d", r.Len(), n+size)
+			}
+			if err := r.UnreadRuneUTF16(); !errors.Is(err, ErrUnreadRune) {
+				t.Errorf("second UnreadRuneUTF16 = %v; want %v", err, ErrUnreadRune)
+			}
+			if ch2, _, _ := r.ReadRuneUTF16(binary.BigEndian); ch2 != ch {
+				t.Errorf("ReadRuneUTF16 after unread = %U; want %U", ch2, ch)
+			}
+		}
+
+		if _, _, err := r.ReadRuneUTF16(binary.BigEndian); err != io.EOF {
+			t.Errorf("at EOF: ReadRuneUTF16 error = %v; want EOF", err)
+		}
+		if err := r.UnreadRuneUTF16(); !errors.Is(err, ErrUnreadRune) {
+			t.Errorf("UnreadRuneUTF16 after EOF = %v; want %v", err, ErrUnreadRune)
+		}
+
+		r = New(S("\x00\x61"))
+		_, _, _ = r.ReadRuneUTF16(binary.LittleEndian)
+		if err := r.UnreadRune(); !errors.Is(err, ErrUnreadRune) {
+			t.Errorf("UnreadRune after ReadRuneUTF16 = %v; want %v", err, ErrUnreadRune)
+		}
+	})
+}
+
+func TestReaderReadBOM(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadBOM[[]byte](t)
+	testReaderReadBOM[string](t)
+}
+
+func testReaderReadBOM[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		order   binary.ByteOrder
+		ok      bool
+		err     error
+		wantLen int
+	}{
+		{"\xff\xfeh\x00", binary.LittleEndian, true, nil, 2},
+		{"\xfe\xff\x00h", binary.BigEndian, true, nil, 2},
+		{"\xef\xbb\xbfh", nil, false, nil, 4},
+		{"hi", nil, false, nil, 2},
+		{"\xff", nil, false, nil, 1},
+		{"h", nil, false, nil, 1},
+		{"", nil, false, io.EOF, 0},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			order, ok, err := r.ReadBOM()
+			if tt.order != order || tt.ok != ok || tt.err != err {
+				t.Errorf("ReadBOM(%q) = %v, %t, %v; want %v, %t, %v", tt.s, order, ok, err, tt.order, tt.ok, tt.err)
+			}
+			if r.Len() != tt.wantLen {
+				t.Errorf("ReadBOM(%q): Len = %d; want %d", tt.s, r.Len(), tt.wantLen)
+			}
+		}
+
+		// The detected order decodes the rest of the data.
+		r := New(S("\xfe\xff\x00h\x00i"))
+		order, _, _ := r.ReadBOM()
+		if ch, _, err := r.ReadRuneUTF16(order); ch != 'h' || err != nil {
+			t.Errorf("ReadRuneUTF16 after ReadBOM = %q, %v; want 'h', nil", ch, err)
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package reader
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// SetStrictUTF8 sets whether invalid UTF-8 is rejected by the methods
+// that decode runes. By default, as in the standard library, each byte
+// of an invalid sequence, including overlong encodings, encoded
+// surrogates and a multi-byte rune cut short by the end of the data,
+// is decoded as utf8.RuneError of width 1. In strict mode ReadRune and
+// ReadNRunes instead return an *InvalidUTF8Error, leaving the Reader at
+// the start of the invalid sequence, and Runes and FieldsFuncSeq stop
+// there and report it through Err.
+// Strict mode does not affect the methods that only measure or classify
+// the data, such as RuneLen and CountFunc, nor Words and SkipWhitespace,
+// which look for ASCII white space byte by byte.
+func (r *Reader[S]) SetStrictUTF8(strict bool) { r.options().strictUTF8 = strict }
+
+// invalidRune reports whether ch and size, as returned by
+// utf8.DecodeRune, must be rejected as invalid UTF-8 in strict mode.
+func (r *Reader[S]) invalidRune(ch rune, size int) bool {
+	return ch == utf8.RuneError && size == 1 && r.opts != nil && r.opts.strictUTF8
+}
+
+// RuneLen returns the number of runes in the unread portion of the
+// slice or string. Erroneous and short encodings are treated as single
+// runes of width 1 byte, as with utf8.RuneCount.
+// RuneLen counts the runes afresh on each call; see RuneCount for a
+// count that is remembered between calls.
+// RuneLen does not modify the Reader.
+func (r *Reader[S]) RuneLen() int {
+	return utf8.RuneCountInString(asString(r.unread()))
+}
+
+// RuneCount returns the number of runes in the unread portion of the
+// slice or string, counted as by RuneLen. The count is remembered, and a later call counts only the
+// runes read since, so that calling RuneCount while reading forward
+// takes constant time per rune read; after seeking backward the runes
+// are counted again.
+// RuneCount does not modify the position of the Reader, but it updates
+// the remembered count, so unlike RuneLen it must not be called
+// concurrently with other methods.
+func (r *Reader[S]) RuneCount() int64 {
+	o := r.options()
+	if o.runeCountOK && o.runeCountOff < r.off && r.off <= r.end() {
+		// Subtract the runes read since the count was taken, provided
+		// that decoding from there lands on the current offset.
+		s := asString(r.s[o.runeCountOff:r.end()])
+		i, n, m := 0, int64(0), int(r.off-o.runeCountOff)
+		for i < m {
+			if s[i] < utf8.RuneSelf {
+				i++
+			} else {
+				_, size := utf8.DecodeRuneInString(s[i:])
+				i += size
+			}
+			n++
+		}
+		if i == m {
+			o.runeCountOff = r.off
+			o.runeCount -= n
+		}
+	}
+	if !o.runeCountOK || o.runeCountOff != r.off {
+		o.runeCount = int64(utf8.RuneCountInString(asString(r.unread())))
+		o.runeCountOff = r.off
+		o.runeCountOK = true
+	}
+	return o.runeCount
+}
+
+// forgetRuneCount discards the count remembered by RuneCount, for use
+// when the end of the unread data moves.
+func (r *Reader[S]) forgetRuneCount() {
+	if r.opts != nil {
+		r.opts.runeCountOK = false
+	}
+}
+
+// CountFunc returns the number of runes in the unread data for which f
+// returns true, decoding the data in place in a single pass. An invalid
+// UTF-8 byte is passed to f as utf8.RuneError.
+// CountFunc does not modify the Reader.
+func (r *Reader[S]) CountFunc(f func(rune) bool) int64 {
+	var n int64
+	for _, ch := range asString(r.unread()) {
+		if f(ch) {
+			n++
+		}
+	}
+	return n
+}
+
+// peekRune decodes the rune at the current offset without advancing.
+// The Reader must not be at EOF.
+func (r *Reader[S]) peekRune() (rune, int) {
+	if c := r.s[r.off]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(asString(r.unread()))
+}
+
+// ReadLastRune reads the last rune of the unread portion of the slice
+// or string and moves the end of the unread data back to its start, so
+// that it is not read again from either end. The underlying data is
+// left intact: Size, ReadAt and the other methods that address it by
+// absolute offset still see the runes read from the end.
+// Invalid UTF-8 is decoded as utf8.RuneError of width 1, as with
+// ReadRune, or in strict UTF-8 mode yields an *InvalidUTF8Error.
+// If no bytes remain, ReadLastRune returns io.EOF.
+func (r *Reader[S]) ReadLastRune() (ch rune, size int, err error) {
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	if len(s) == 0 {
+		return 0, 0, io.EOF
+	}
+
+	ch, size = rune(s[len(s)-1]), 1
+	if ch >= utf8.RuneSelf {
+		ch, size = utf8.DecodeLastRuneInString(s)
+		if r.invalidRune(ch, size) {
+			return 0, 0, &InvalidUTF8Error{Offset: r.end() - 1}
+		}
+	}
+	r.tail += int64(size)
+	r.forgetRuneCount()
+	r.lastRead = opReadLastRune1 - readOp(size-1)
+	r.stats().readRune(size)
+	return ch, size, nil
+}
+
+// UnreadLastRune unreads the rune returned by the last call to
+// ReadLastRune, restoring the end of the unread data. If the most recent method
+// called on the Reader was not a successful ReadLastRune, UnreadLastRune
+// returns an error.
+func (r *Reader[S]) UnreadLastRune() error {
+	switch r.lastRead {
+	default:
+		return &ReaderError{Method: "UnreadLastRune", Offset: r.end(), Cause: ErrUnreadRune}
+	case opReadLastRune1, opReadLastRune2, opReadLastRune3, opReadLastRune4:
+	}
+
+	r.tail -= int64(opReadLastRune1-r.lastRead) + 1
+	r.forgetRuneCount()
+	r.lastRead = opInvalid
+	return nil
+}
+
+// ReadNRunes reads exactly n runes and returns them as a string.
+// For a Reader[string] the result is a sub-string of the underlying
+// string and does not allocate.
+// If fewer than n runes remain, ReadNRunes returns the runes read and
+// io.ErrUnexpectedEOF, or io.EOF if no runes were read.
+// In strict UTF-8 mode, reading stops before an invalid sequence, and
+// the runes read so far are returned with an *InvalidUTF8Error.
+func (r *Reader[S]) ReadNRunes(n int) (str string, err error) {
+	if n < 0 {
+		return "", &ReaderError{Method: "ReadNRunes", Offset: r.off, Cause: ErrNegativeCount}
+	}
+
+	r.lastRead = opInvalid
+	s := asString(r.unread())
+	i := 0
+	for ; n > 0 && i < len(s); n-- {
+		if s[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		ch, size := utf8.DecodeRuneInString(s[i:])
+		if r.invalidRune(ch, size) {
+			err = &InvalidUTF8Error{Offset: r.off + int64(i)}
+			break
+		}
+		i += size
+	}
+	if i == 0 && n > 0 && err == nil {
+		return "", io.EOF
+	}
+
+	str = string(r.s[r.off : r.off+int64(i)])
+	r.off += int64(i)
+	if err != nil {
+		return str, err
+	}
+	if n > 0 {
+		return str, io.ErrUnexpectedEOF
+	}
+	return str, nil
+}
+
+// ValidUTF8 reports whether the unread portion of the slice or string
+// consists entirely of valid UTF-8-encoded runes. If it does not,
+// firstInvalid is the absolute byte offset of the first invalid sequence;
+// otherwise it is -1. ValidUTF8 does not modify the Reader.
+func (r *Reader[S]) ValidUTF8() (ok bool, firstInvalid int64) {
+	s := asString(r.unread())
+	if utf8.ValidString(s) {
+		return true, -1
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		ch, size := utf8.DecodeRuneInString(s[i:])
+		if ch == utf8.RuneError && size == 1 {
+			return false, r.off + int64(i)
+		}
+		i += size
+	}
+	panic("unreachable")
+}
+
+// RequireUTF8 returns nil if the unread data is valid UTF-8, and
+// otherwise an *InvalidUTF8Error holding the absolute byte offset of the
+// first invalid sequence, the same error strict UTF-8 mode reports, so
+// that a guard clause takes one line:
+//
+//	if err := r.RequireUTF8(); err != nil {
+//		return err
+//	}
+//
+// Use ValidateUTF8 for a *ReaderError instead.
+// RequireUTF8 does not modify the Reader.
+func (r *Reader[S]) RequireUTF8() error {
+	if ok, off := r.ValidUTF8(); !ok {
+		return &InvalidUTF8Error{Offset: off}
+	}
+	return nil
+}
+
+// ValidateUTF8 is like ValidUTF8 but returns nil if the unread data is
+// valid UTF-8, and otherwise a *ReaderError wrapping ErrInvalidUTF8 that
+// holds the absolute byte offset of the first invalid sequence.
+// ValidateUTF8 does not modify the Reader.
+func (r *Reader[S]) ValidateUTF8() error {
+	if ok, off := r.ValidUTF8(); !ok {
+		return &ReaderError{Method: "ValidateUTF8", Offset: off, Cause: ErrInvalidUTF8}
+	}
+	return nil
+}
+
+// IsValidASCII reports whether every unread byte is in the range [0x00, 0x7F].
+// IsValidASCII does not modify the Reader.
+func (r *Reader[S]) IsValidASCII() bool {
+	s := asString(r.unread())
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,499 @@
+package reader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/weiwenchen2022/reader"
+)
+
+func TestReaderRuneLen(t *testing.T) {
+	t.Parallel()
+
+	testReaderRuneLen[[]byte](t)
+	testReaderRuneLen[string](t)
+}
+
+func testReaderRuneLen[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("a世界\xff!"))
+		if n := r.RuneLen(); n != 5 {
+			t.Errorf("RuneLen = %d; want 5", n)
+		}
+		if _, _, err := r.ReadRune(); err != nil {
+			t.Fatal(err)
+		}
+		if n := r.RuneLen(); n != 4 {
+			t.Errorf("after ReadRune: RuneLen = %d; want 4", n)
+		}
+		// RuneLen must not disturb UnreadRune.
+		if err := r.UnreadRune(); err != nil {
+			t.Errorf("UnreadRune after RuneLen: %v", err)
+		}
+		if _, err := r.Seek(100, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		if n := r.RuneLen(); n != 0 {
+			t.Errorf("past end: RuneLen = %d; want 0", n)
+		}
+	})
+}
+
+func TestReaderRuneLenAllocs(t *testing.T) {
+	r := New("こんにちは、世界")
+	if n := testing.AllocsPerRun(100, func() { _ = r.RuneLen() }); n != 0 {
+		t.Errorf("RuneLen allocs = %v; want 0", n)
+	}
+}
+
+func TestReaderRuneCount(t *testing.T) {
+	t.Parallel()
+
+	testReaderRuneCount[[]byte](t)
+	testReaderRuneCount[string](t)
+}
+
+func testReaderRuneCount[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	const s = "a世界\xff!\xe4\xb8"
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S(s))
+		check := func(step string) {
+			t.Helper()
+			off, _ := r.Seek(0, io.SeekCurrent)
+			end := min(off, r.Size()) + int64(r.Len())
+			want := int64(utf8.RuneCountInString(s[min(off, end):end]))
+			if n := r.RuneCount(); n != want {
+				t.Errorf("%s: RuneCount = %d; want %d", step, n, want)
+			}
+		}
+
+		check("start")
+		for {
+			if _, _, err := r.ReadRune(); err != nil {
+				break
+			}
+			check("after ReadRune")
+		}
+		_, _ = r.Seek(2, io.SeekStart) // inside '世'
+		check("after Seek")
+		_, _, _ = r.ReadRune()
+		check("after ReadRune inside a rune")
+		_ = r.UnreadRune()
+		check("after UnreadRune")
+		_, _ = r.ReadByte()
+		check("after ReadByte")
+		_, _, _ = r.ReadLastRune()
+		check("after ReadLastRune")
+		_, _ = r.Seek(100, io.SeekStart)
+		check("past end")
+	})
+}
+
+func BenchmarkReaderRuneCount(b *testing.B) {
+	b.ReportAllocs()
+	r := New(runesData)
+	for i := 0; i < b.N; i++ {
+		if _, _, err := r.ReadRune(); err != nil {
+			r.Reset(runesData)
+		}
+		_ = r.RuneCount()
+	}
+}
+
+func BenchmarkReaderRuneLen(b *testing.B) {
+	b.ReportAllocs()
+	r := New(runesData)
+	for i := 0; i < b.N; i++ {
+		if _, _, err := r.ReadRune(); err != nil {
+			r.Reset(runesData)
+		}
+		_ = r.RuneLen()
+	}
+}
+
+func TestReaderCountFunc(t *testing.T) {
+	t.Parallel()
+
+	testReaderCountFunc[[]byte](t)
+	testReaderCountFunc[string](t)
+}
+
+func testReaderCountFunc[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("skip:a1 世界\xff9"))
+		_, _ = r.Seek(5, io.SeekStart)
+		tests := []struct {
+			name string
+			f    func(rune) bool
+			want int64
+		}{
+			{"IsDigit", unicode.IsDigit, 2},
+			{"non-ASCII", func(c rune) bool { return c >= utf8.RuneSelf }, 3},
+			{"RuneError", func(c rune) bool { return c == utf8.RuneError }, 1},
+			{"all", func(rune) bool { return true }, 7},
+		}
+		for _, tt := range tests {
+			if got := r.CountFunc(tt.f); got != tt.want {
+				t.Errorf("CountFunc(%s) = %d; want %d", tt.name, got, tt.want)
+			}
+		}
+		if r.Len() != 11 {
+			t.Errorf("CountFunc modified the Reader: Len = %d; want 11", r.Len())
+		}
+	})
+}
+
+func TestReaderCountFuncAllocs(t *testing.T) {
+	r := New(runesData)
+	if n := testing.AllocsPerRun(10, func() {
+		_ = r.CountFunc(unicode.IsLetter)
+	}); n != 0 {
+		t.Errorf("CountFunc allocs = %v; want 0", n)
+	}
+}
+
+func BenchmarkReaderCountFunc(b *testing.B) {
+	b.SetBytes(int64(len(runesData)))
+	b.ReportAllocs()
+	r := New(runesData)
+	for i := 0; i < b.N; i++ {
+		_ = r.CountFunc(unicode.IsLetter)
+	}
+}
+
+func BenchmarkRangeStringCount(b *testing.B) {
+	b.SetBytes(int64(len(runesData)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for _, c := range string(runesData) {
+			if unicode.IsLetter(c) {
+				n++
+			}
+		}
+		_ = n
+	}
+}
+
+func TestReaderReadLastRune(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadLastRune[[]byte](t)
+	testReaderReadLastRune[string](t)
+}
+
+func testReaderReadLastRune[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		r := New(S("ab\xe4\xb8"))
+		want := []struct {
+			ch   rune
+			size int
+		}{{utf8.RuneError, 1}, {utf8.RuneError, 1}, {'b', 1}, {'a', 1}}
+		for _, w := range want {
+			if ch, size, err := r.ReadLastRune(); w.ch != ch || w.size != size || err != nil {
+				t.Errorf("ReadLastRune = %U, %d, %v; want %U, %d, nil", ch, size, err, w.ch, w.size)
+			}
+		}
+		if _, _, err := r.ReadLastRune(); err != io.EOF {
+			t.Errorf("ReadLastRune at EOF error = %v; want EOF", err)
+		}
+		if err := r.UnreadLastRune(); !errors.Is(err, ErrUnreadRune) {
+			t.Errorf("UnreadLastRune after EOF = %v; want %v", err, ErrUnreadRune)
+		}
+
+		r = New(S("世"))
+		if ch, size, err := r.ReadLastRune(); ch != '世' || size != 3 || err != nil {
+			t.Errorf("ReadLastRune = %U, %d, %v; want %U, 3, nil", ch, size, err, '世')
+		}
+		if r.Len() != 0 || r.Size() != 3 {
+			t.Errorf("Len, Size after ReadLastRune = %d, %d; want 0, 3", r.Len(), r.Size())
+		}
+		var buf [3]byte
+		if n, err := r.ReadAt(buf[:], 0); n != 3 || err != nil || string(buf[:]) != "世" {
+			t.Errorf("ReadAt after ReadLastRune = %d, %v, %q; want 3, nil, %q", n, err, buf[:n], "世")
+		}
+		if err := r.UnreadLastRune(); err != nil {
+			t.Fatalf("UnreadLastRune = %v", err)
+		}
+		if err := r.UnreadLastRune(); !errors.Is(err, ErrUnreadRune) {
+			t.Errorf("second UnreadLastRune = %v; want %v", err, ErrUnreadRune)
+		}
+		if ch, size, err := r.ReadRune(); ch != '世' || size != 3 || err != nil {
+			t.Errorf("ReadRune after UnreadLastRune = %U, %d, %v; want %U, 3, nil", ch, size, err, '世')
+		}
+
+		r = New(S("  héllo, 世界\t "))
+		r.SkipWhitespace()
+		for {
+			ch, _, err := r.ReadLastRune()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !unicode.IsSpace(ch) {
+				_ = r.UnreadLastRune()
+				break
+			}
+		}
+		var got []rune
+		for i := 0; ; i++ {
+			var ch rune
+			var err error
+			if i%2 == 0 {
+				ch, _, err = r.ReadRune()
+			} else {
+				ch, _, err = r.ReadLastRune()
+			}
+			if err == io.EOF {
+				break
+			}
+			got = append(got, ch)
+		}
+		if want := "h界é世l l,o"; string(got) != want {
+			t.Errorf("interleaved ReadRune and ReadLastRune = %q; want %q", string(got), want)
+		}
+
+		r = New(S("a\xc0\xaf"))
+		r.SetStrictUTF8(true)
+		var e *InvalidUTF8Error
+		if ch, size, err := r.ReadLastRune(); ch != 0 || size != 0 || !errors.As(err, &e) || e.Offset != 2 {
+			t.Errorf("strict ReadLastRune = %U, %d, %v; want 0, 0, InvalidUTF8Error at 2", ch, size, err)
+		}
+		if r.Size() != 3 {
+			t.Errorf("Size after strict ReadLastRune = %d; want 3", r.Size())
+		}
+	})
+}
+
+func TestReaderReadNRunes(t *testing.T) {
+	t.Parallel()
+
+	testReaderReadNRunes[[]byte](t)
+	testReaderReadNRunes[string](t)
+}
+
+func testReaderReadNRunes[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		n       int
+		want    string
+		wanterr any
+		wantlen int
+	}{
+		{0, "", nil, 9},
+		{1, "a", nil, 8},
+		{3, "a世界", nil, 2},
+		{5, "a世界\xff!", nil, 0},
+		{6, "a世界\xff!", io.ErrUnexpectedEOF, 0},
+		{-1, "", "reader.Reader.ReadNRunes: negative count at offset 0", 9},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S("a世界\xff!"))
+			s, err := r.ReadNRunes(tt.n)
+			if tt.want != s {
+				t.Errorf("ReadNRunes(%d) = %q; want %q", tt.n, s, tt.want)
+			}
+			if fmt.Sprint(tt.wanterr) != fmt.Sprint(err) {
+				t.Errorf("ReadNRunes(%d) error = %v; want %v", tt.n, err, tt.wanterr)
+			}
+			if r.Len() != tt.wantlen {
+				t.Errorf("ReadNRunes(%d): Len = %d; want %d", tt.n, r.Len(), tt.wantlen)
+			}
+		}
+
+		r := New(S("a世界\xff!"))
+		if _, err := r.Seek(0, io.SeekEnd); err != nil {
+			t.Fatal(err)
+		}
+		if s, err := r.ReadNRunes(1); s != "" || err != io.EOF {
+			t.Errorf("at EOF: ReadNRunes = %q, %v; want \"\", EOF", s, err)
+		}
+	})
+}
+
+func TestReaderReadNRunesAllocs(t *testing.T) {
+	r := New("héllo, wörld")
+	if n := testing.AllocsPerRun(100, func() {
+		r.Reset("héllo, wörld")
+		_, _ = r.ReadNRunes(7)
+	}); n != 0 {
+		t.Errorf("ReadNRunes allocs = %v; want 0", n)
+	}
+}
+
+func TestReaderValidateUTF8(t *testing.T) {
+	t.Parallel()
+
+	testReaderValidateUTF8[[]byte](t)
+	testReaderValidateUTF8[string](t)
+}
+
+func testReaderValidateUTF8[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		s       string
+		off     int64
+		first   int64
+		wanterr any
+		ascii   bool
+	}{
+		{"", 0, -1, nil, true},
+		{"hello", 0, -1, nil, true},
+		{"héllo, 世界", 0, -1, nil, false},
+		{"�", 0, -1, nil, false},
+		{"abc\xffdef", 0, 3, "reader.Reader.ValidateUTF8: invalid UTF-8 at offset 3", false},
+		{"abc\xffdef", 4, -1, nil, true},
+		{"ab世\xe4\xb8", 1, 5, "reader.Reader.ValidateUTF8: invalid UTF-8 at offset 5", false},
+		{"\xed\xa0\x80", 0, 0, "reader.Reader.ValidateUTF8: invalid UTF-8 at offset 0", false},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			if _, err := r.Seek(tt.off, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+
+			if ok, first := r.ValidUTF8(); (tt.first < 0) != ok || tt.first != first {
+				t.Errorf("ValidUTF8(%q) = %t, %d; want %t, %d", tt.s[tt.off:], ok, first, tt.first < 0, tt.first)
+			}
+			if err := r.ValidateUTF8(); fmt.Sprint(tt.wanterr) != fmt.Sprint(err) {
+				t.Errorf("ValidateUTF8(%q) = %v; want %v", tt.s[tt.off:], err, tt.wanterr)
+			}
+			var e *InvalidUTF8Error
+			if err := r.RequireUTF8(); (tt.first < 0) != (err == nil) || err != nil && (!errors.As(err, &e) || e.Offset != tt.first) {
+				t.Errorf("RequireUTF8(%q) = %v; want InvalidUTF8Error at %d, or nil if -1", tt.s[tt.off:], err, tt.first)
+			}
+			if ascii := r.IsValidASCII(); tt.ascii != ascii {
+				t.Errorf("IsValidASCII(%q) = %t; want %t", tt.s[tt.off:], ascii, tt.ascii)
+			}
+			if int64(r.Len()) != int64(len(tt.s))-tt.off {
+				t.Errorf("Len = %d; want %d", r.Len(), int64(len(tt.s))-tt.off)
+			}
+		}
+	})
+}
+
+func TestReaderStrictUTF8(t *testing.T) {
+	t.Parallel()
+
+	testReaderStrictUTF8[[]byte](t)
+	testReaderStrictUTF8[string](t)
+}
+
+func testReaderStrictUTF8[S ~[]byte | ~string](t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		s    string
+		off  int64 // offset of the invalid sequence
+	}{
+		{"overlong", "ab\xc0\xafcd", 2},
+		{"surrogate", "ab\xed\xa0\x80cd", 2},
+		{"truncated at EOF", "ab\xe4\xb8", 2},
+	}
+
+	t.Run(fmt.Sprintf("%T", *new(S)), func(t *testing.T) {
+		for _, tt := range tests {
+			r := New(S(tt.s))
+			// By default invalid bytes are decoded one at a time as RuneError.
+			_, _ = r.Seek(tt.off, io.SeekStart)
+			if ch, size, err := r.ReadRune(); ch != utf8.RuneError || size != 1 || err != nil {
+				t.Errorf("%s: ReadRune = %U, %d, %v; want %U, 1, nil", tt.name, ch, size, err, utf8.RuneError)
+			}
+
+			r.SetStrictUTF8(true)
+			_, _ = r.Seek(0, io.SeekStart)
+			for range tt.off {
+				if _, _, err := r.ReadRune(); err != nil {
+					t.Fatalf("%s: ReadRune before invalid sequence: %v", tt.name, err)
+				}
+			}
+			ch, size, err := r.ReadRune()
+			var e *InvalidUTF8Error
+			if ch != 0 || size != 0 || !errors.As(err, &e) || e.Offset != tt.off {
+				t.Errorf("%s: strict ReadRune = %U, %d, %v; want 0, 0, InvalidUTF8Error at %d", tt.name, ch, size, err, tt.off)
+			}
+			if !errors.Is(err, ErrInvalidUTF8) {
+				t.Errorf("%s: strict ReadRune error = %v; want one wrapping %v", tt.name, err, ErrInvalidUTF8)
+			}
+			if r.Len() != len(tt.s)-int(tt.off) {
+				t.Errorf("%s: Len after strict ReadRune = %d; want %d", tt.name, r.Len(), len(tt.s)-int(tt.off))
+			}
+			if err := r.UnreadRune(); err == nil {
+				t.Errorf("%s: UnreadRune after failed ReadRune succeeded", tt.name)
+			}
+
+			_, _ = r.Seek(0, io.SeekStart)
+			str, err := r.ReadNRunes(10)
+			if str != tt.s[:tt.off] || !errors.As(err, &e) || e.Offset != tt.off {
+				t.Errorf("%s: strict ReadNRunes = %q, %v; want %q, InvalidUTF8Error at %d", tt.name, str, err, tt.s[:tt.off], tt.off)
+			}
+
+			r.SetStrictUTF8(false)
+			if ch, size, err := r.ReadRune(); ch != utf8.RuneError || size != 1 || err != nil {
+				t.Errorf("%s: ReadRune after SetStrictUTF8(false) = %U, %d, %v; want %U, 1, nil", tt.name, ch, size, err, utf8.RuneError)
+			}
+
+			r = New(S(tt.s))
+			r.SetStrictUTF8(true)
+			var n int64
+			for off := range r.Runes() {
+				if off >= tt.off {
+					t.Errorf("%s: Runes yielded offset %d past the invalid sequence", tt.name, off)
+				}
+				n++
+			}
+			if n != tt.off || r.Len() != len(tt.s)-int(tt.off) {
+				t.Errorf("%s: strict Runes yielded %d runes, Len %d; want %d, %d", tt.name, n, r.Len(), tt.off, len(tt.s)-int(tt.off))
+			}
+			if err := r.Err(); !errors.As(err, &e) || e.Offset != tt.off {
+				t.Errorf("%s: Err after strict Runes = %v; want InvalidUTF8Error at %d", tt.name, err, tt.off)
+			}
+
+			r.Reset(S(tt.s))
+			var fields []string
+			for f := range r.FieldsFuncSeq(unicode.IsSpace) {
+				fields = append(fields, string(f))
+			}
+			if len(fields) != 0 || !errors.As(r.Err(), &e) || e.Offset != tt.off {
+				t.Errorf("%s: strict FieldsFuncSeq = %q, Err %v; want none, InvalidUTF8Error at %d", tt.name, fields, r.Err(), tt.off)
+			}
+			for range r.Runes() {
+			}
+			if err := r.Err(); !errors.As(err, &e) {
+				t.Errorf("%s: Err after Runes at the invalid sequence = %v; want InvalidUTF8Error", tt.name, err)
+			}
+
+			r.Reset(S(tt.s))
+			tok, err := r.ScanState(0, false).Token(false, nil)
+			if string(tok) != tt.s[:tt.off] || !errors.As(err, &e) || e.Offset != tt.off {
+				t.Errorf("%s: strict ScanState.Token = %q, %v; want %q, InvalidUTF8Error at %d", tt.name, tok, err, tt.s[:tt.off], tt.off)
+			}
+
+			r.Reset(S(tt.s[:tt.off]))
+			for range r.Runes() {
+			}
+			if err := r.Err(); err != nil {
+				t.Errorf("%s: Err after Runes over valid data = %v; want nil", tt.name, err)
+			}
+		}
+	})
+}